@@ -0,0 +1,330 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cassetteVersion = 2
+	redacted        = "REDACTED"
+)
+
+var (
+	// body fields replaced before an interaction is written to a cassette
+	redactedFields = []string{"username", "password", "currentPassword", "secretAccessKey", "passphrase"}
+	// headers replaced before an interaction is written to a cassette
+	redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+)
+
+// cassette is the file format of recorded interactions
+type cassette struct {
+	Version      int           `json:"version"`
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	recordedBody
+}
+
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	recordedBody
+}
+
+// recordedBody holds a JSON body as JSON and any other body as text, so it is replayed byte for byte
+type recordedBody struct {
+	Body    json.RawMessage `json:"body,omitempty"`
+	RawBody string          `json:"rawBody,omitempty"`
+}
+
+// WithRecorder records every request and response to the cassette at path, overwriting it.
+// Credentials, tokens, usernames, passwords and secret access keys are redacted before writing.
+func WithRecorder(path string) ClientOption {
+	return func(c *Client) {
+		c.transports = append(c.transports, func(next http.RoundTripper) http.RoundTripper {
+			return &recorder{next: next, path: path, cassette: cassette{Version: cassetteVersion, Interactions: []interaction{}}}
+		})
+	}
+}
+
+// WithReplayer answers requests from the cassette at path instead of contacting the grid.
+// Requests are matched by method, path, query and redacted body; unmatched requests fail.
+func WithReplayer(path string) ClientOption {
+	return func(c *Client) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			c.err = fmt.Errorf("failed to read cassette: %w", err)
+			return
+		}
+
+		loaded := cassette{}
+		err = json.Unmarshal(data, &loaded)
+		if err != nil {
+			c.err = fmt.Errorf("failed to parse cassette %s: %w", path, err)
+			return
+		}
+		if loaded.Version != cassetteVersion {
+			c.err = fmt.Errorf("unsupported cassette version %d", loaded.Version)
+			return
+		}
+
+		c.transports = append(c.transports, func(http.RoundTripper) http.RoundTripper {
+			return &replayer{cassette: loaded, used: make([]bool, len(loaded.Interactions))}
+		})
+	}
+}
+
+type recorder struct {
+	next     http.RoundTripper
+	path     string
+	mu       sync.Mutex
+	cassette cassette
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+
+	recorded := interaction{
+		Request: newRecordedRequest(req, reqBody),
+		Response: recordedResponse{
+			Status:       resp.StatusCode,
+			Header:       header,
+			recordedBody: newRecordedBody(redactBody(respBody, isAuthorize(req.URL.Path))),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, recorded)
+	err = r.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// save writes the whole cassette, so it is complete after every request
+func (r *recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	err = os.WriteFile(r.path, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+type replayer struct {
+	mu       sync.Mutex
+	cassette cassette
+	used     []bool
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	wanted := newRecordedRequest(req, reqBody)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// interactions are replayed in recording order, repeating the last match once all are used
+	match := -1
+	for i, recorded := range r.cassette.Interactions {
+		if !recorded.Request.equal(wanted) {
+			continue
+		}
+
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, req.URL.RequestURI())
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// recorded tokens have expired long ago
+	if header.Get("Expires") != "" {
+		header.Set("Expires", time.Now().Add(time.Hour).UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT"))
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(recorded.bytes())),
+		ContentLength: -1,
+		Request:       req,
+	}, nil
+}
+
+func newRecordedRequest(req *http.Request, body []byte) recordedRequest {
+	return recordedRequest{
+		Method:       req.Method,
+		Path:         req.URL.EscapedPath(),
+		Query:        req.URL.Query().Encode(),
+		recordedBody: newRecordedBody(redactBody(body, false)),
+	}
+}
+
+func (r recordedRequest) equal(other recordedRequest) bool {
+	return r.Method == other.Method && r.Path == other.Path && r.Query == other.Query &&
+		bytes.Equal(compactJSON(r.Body), compactJSON(other.Body)) && r.RawBody == other.RawBody
+}
+
+// readBody reads and replaces body, so it can be read again
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactBody replaces sensitive fields of a JSON body. Authorization responses carry the token as data.
+func redactBody(body []byte, authorization bool) []byte {
+	var decoded interface{}
+	if len(body) == 0 || json.Unmarshal(body, &decoded) != nil {
+		return body
+	}
+
+	decoded = redactValue(decoded)
+	if object, ok := decoded.(map[string]interface{}); ok && authorization {
+		if _, ok := object["data"].(string); ok {
+			object["data"] = redacted
+		}
+	}
+
+	redactedBody, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+
+	return redactedBody
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isRedactedField(key) {
+				if _, ok := nested.(string); ok {
+					v[key] = redacted
+					continue
+				}
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+
+	return value
+}
+
+func isRedactedField(key string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAuthorize(path string) bool {
+	return strings.HasSuffix(path, "/authorize")
+}
+
+func newRecordedBody(body []byte) recordedBody {
+	if len(body) == 0 {
+		return recordedBody{}
+	}
+	if json.Valid(body) {
+		return recordedBody{Body: compactJSON(body)}
+	}
+
+	return recordedBody{RawBody: string(body)}
+}
+
+// bytes returns the body as it was recorded
+func (b recordedBody) bytes() []byte {
+	if b.RawBody != "" {
+		return []byte(b.RawBody)
+	}
+
+	return b.Body
+}
+
+func compactJSON(data []byte) []byte {
+	buf := bytes.Buffer{}
+	if json.Compact(&buf, data) != nil {
+		return data
+	}
+
+	return buf.Bytes()
+}
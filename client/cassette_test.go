@@ -0,0 +1,66 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassette_BodiesReplayVerbatim(t *testing.T) {
+	bodies := map[string]string{
+		"/json":   `{"data":"text","status":"success"}`,
+		"/string": `"a JSON string"`,
+		"/text":   "plain text",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, bodies[r.URL.Path])
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec := &recorder{next: http.DefaultTransport, path: path, cassette: cassette{Version: cassetteVersion, Interactions: []interaction{}}}
+	for p := range bodies {
+		resp, err := rec.RoundTrip(httptest.NewRequest("GET", server.URL+p, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	c := &Client{}
+	WithReplayer(path)(c)
+	if c.err != nil {
+		t.Fatal(c.err)
+	}
+	replay := c.transports[0](nil)
+
+	for p, want := range bodies {
+		t.Run(strings.TrimPrefix(p, "/"), func(t *testing.T) {
+			resp, err := replay.RoundTrip(httptest.NewRequest("GET", "https://storagegrid.invalid"+p, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// JSON bodies are stored indented, everything else byte for byte
+			if string(compactJSON(got)) != want {
+				t.Errorf("Expected body %q, got %q", want, got)
+			}
+		})
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"rawBody": "plain text"`) {
+		t.Errorf("Expected the text body to be recorded as rawBody, got %s", data)
+	}
+}
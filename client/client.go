@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
 )
 
 var (
@@ -30,6 +31,17 @@ type Client struct {
 	token        string
 	tokenExpires time.Time
 	mu           sync.Mutex
+
+	// transports wrap the HTTP transport, e.g. to record or replay requests
+	transports []func(http.RoundTripper) http.RoundTripper
+	// fromEnv, fromProfile, profile, configFile and caBundle are resolved when the client is created
+	fromEnv     bool
+	fromProfile bool
+	profile     string
+	configFile  string
+	caBundle    string
+	// err is set by options that cannot be applied and returned when the client is created
+	err error
 }
 
 type ClientOption func(*Client)
@@ -63,13 +75,22 @@ func WithEndpoint(endpoint string) ClientOption {
 
 func newClient(options ...ClientOption) (*Client, error) {
 	c := &Client{
-		httpClient: http.DefaultClient,
+		httpClient: &http.Client{},
 	}
 
 	for _, option := range options {
 		option(c)
 	}
 
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	err := c.resolveSettings()
+	if err != nil {
+		return nil, err
+	}
+
 	// err if no endpoint is set
 	if c.baseURL == nil {
 		return nil, fmt.Errorf("no endpoint set")
@@ -79,8 +100,17 @@ func newClient(options ...ClientOption) (*Client, error) {
 		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.skipSSL}, // #nosec G402
 	}
+	if c.caBundle != "" {
+		transCfg.TLSClientConfig.RootCAs, err = loadCABundle(c.caBundle)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	c.httpClient.Transport = transCfg
+	for _, wrap := range c.transports {
+		c.httpClient.Transport = wrap(c.httpClient.Transport)
+	}
 
 	return c, nil
 }
@@ -114,26 +144,40 @@ func (c *Client) authorize(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) newRequest(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
+func (c *Client) newRequest(ctx context.Context, method string, path string, body interface{}, options *services.RequestOptions) (*http.Response, error) {
 	var err error
 	var reqBody []byte
-	if body != nil {
+	contentType := ""
+	if options.RawBody != nil {
+		reqBody = options.RawBody
+		contentType = options.ContentType
+	} else if body != nil {
 		reqBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
+		contentType = "application/json"
 	}
 
 	// Create a new request
 	surl := c.baseURL.String() + path
-	req, err := http.NewRequest(method, surl, bytes.NewReader(reqBody))
+	if len(options.Query) > 0 {
+		surl = surl + "?" + options.Query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, surl, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for name, values := range options.Header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
 	// Ensure Content-Type is set for requests with a body.
-	if body != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	// if the path is not an authorize endpoint, set the token in the header
@@ -172,21 +216,23 @@ func (c *Client) parseResponse(resp *http.Response, resourceType interface{}) er
 	return nil
 }
 
-func (c *Client) DoUnparsed(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
-	resp, err := c.newRequest(ctx, method, path, body)
+func (c *Client) DoUnparsed(ctx context.Context, method string, path string, body interface{}, opts ...services.RequestOption) (*http.Response, error) {
+	options := services.NewRequestOptions(opts...)
+	resp, err := c.newRequest(ctx, method, path, body, options)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+	if !options.IsExpectedStatus(resp.StatusCode) {
+		resp.Body.Close()
 		return nil, (fmt.Errorf("API error: %s (code: %d)", resp.Status, resp.StatusCode))
 	}
 
 	return resp, nil
 }
 
-func (c *Client) DoParsed(ctx context.Context, method string, path string, body interface{}, output interface{}) error {
-	resp, err := c.DoUnparsed(ctx, method, path, body)
+func (c *Client) DoParsed(ctx context.Context, method string, path string, body interface{}, output interface{}, opts ...services.RequestOption) error {
+	resp, err := c.DoUnparsed(ctx, method, path, body, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,359 @@
+package client
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// Environment variables read by FromEnv and FromProfile
+const (
+	EnvEndpoint   = "STORAGEGRID_ENDPOINT"
+	EnvUsername   = "STORAGEGRID_USERNAME"
+	EnvPassword   = "STORAGEGRID_PASSWORD"
+	EnvAccountId  = "STORAGEGRID_ACCOUNT_ID"
+	EnvSkipSSL    = "STORAGEGRID_SKIP_SSL"
+	EnvCABundle   = "STORAGEGRID_CA_BUNDLE"
+	EnvProfile    = "STORAGEGRID_PROFILE"
+	EnvConfigFile = "STORAGEGRID_CONFIG_FILE"
+)
+
+// DefaultProfile is the profile FromProfile selects when neither a name nor STORAGEGRID_PROFILE is given
+const DefaultProfile = "default"
+
+// config is the configuration file with named profiles, by default ~/.storagegrid/config.yaml
+type config struct {
+	Profiles map[string]settings `yaml:"profiles"`
+}
+
+// settings are the connection settings of a profile or the environment
+type settings struct {
+	Endpoint  string `yaml:"endpoint"`
+	Username  string `yaml:"username"`
+	Password  secret `yaml:"password"`
+	AccountId string `yaml:"accountId"`
+	SkipSSL   *bool  `yaml:"skipSSL"`
+	CABundle  string `yaml:"caBundle"`
+}
+
+// secret is a value given in plain text or read from an environment variable, a file or the output of a command
+type secret struct {
+	Value   string `yaml:"value"`
+	Env     string `yaml:"env"`
+	File    string `yaml:"file"`
+	Command string `yaml:"command"`
+}
+
+// WithConfigFile sets the configuration file read by FromProfile instead of ~/.storagegrid/config.yaml
+func WithConfigFile(path string) ClientOption {
+	return func(c *Client) {
+		c.configFile = path
+	}
+}
+
+// WithCABundle trusts the PEM encoded certificates in the file at path in addition to the system roots
+func WithCABundle(path string) ClientOption {
+	return func(c *Client) {
+		c.caBundle = path
+	}
+}
+
+// FromEnv reads the endpoint, credentials, account ID, CA bundle and TLS verification from STORAGEGRID_* variables.
+// Settings given by other options take precedence over the environment, which takes precedence over FromProfile.
+// Username and password are taken together from the first of these sources that sets either of them.
+func FromEnv() ClientOption {
+	return func(c *Client) {
+		c.fromEnv = true
+	}
+}
+
+// FromProfile reads the connection settings of a named profile from the configuration file.
+// An empty name selects STORAGEGRID_PROFILE or else the default profile, which may be missing.
+// The file is set with WithConfigFile or STORAGEGRID_CONFIG_FILE and defaults to ~/.storagegrid/config.yaml.
+func FromProfile(name string) ClientOption {
+	return func(c *Client) {
+		c.fromProfile = true
+		c.profile = name
+	}
+}
+
+// resolveSettings fills the settings that no option has set from the environment and then from the profile.
+// Username and password are taken together from the first source that sets either of them, which has to set both.
+func (c *Client) resolveSettings() error {
+	s := settings{}
+	// source names the source of the username and password for errors
+	source := ""
+	if c.fromProfile {
+		p, err := loadProfile(c.configFile, c.profile)
+		if err != nil {
+			return err
+		}
+		s = *p
+		if s.hasCredentials() {
+			source = "the profile"
+		}
+	}
+	if c.fromEnv {
+		env, err := envSettings()
+		if err != nil {
+			return err
+		}
+		s.overlay(env)
+		if env.hasCredentials() {
+			source = "the environment"
+		}
+	}
+
+	if c.baseURL == nil && s.Endpoint != "" {
+		WithEndpoint(s.Endpoint)(c)
+	}
+	if !c.skipSSL && s.SkipSSL != nil {
+		c.skipSSL = *s.SkipSSL
+	}
+	if c.caBundle == "" {
+		c.caBundle = s.CABundle
+	}
+
+	// copy the credentials, as they belong to the caller
+	creds := models.Credentials{}
+	if c.credentials != nil {
+		creds = *c.credentials
+	}
+	// username and password belong together, a password of one source never signs in the user of another
+	if creds.Username != "" || creds.Password != "" {
+		err := checkPair("the credentials", creds.Username != "", creds.Password != "")
+		if err != nil {
+			return err
+		}
+	} else if s.hasCredentials() {
+		err := checkPair(source, s.Username != "", s.Password.isSet())
+		if err != nil {
+			return err
+		}
+
+		creds.Username = s.Username
+		if s.Password.isSet() {
+			password, err := s.Password.resolve()
+			if err != nil {
+				return fmt.Errorf("failed to read password: %w", err)
+			}
+			creds.Password = password
+		}
+	}
+	if creds.AccountId == nil && s.AccountId != "" {
+		accountId := s.AccountId
+		creds.AccountId = &accountId
+	}
+	if c.credentials != nil || creds != (models.Credentials{}) {
+		c.credentials = &creds
+	}
+
+	return nil
+}
+
+// hasCredentials reports whether the settings set the username or the password
+func (s *settings) hasCredentials() bool {
+	return s.Username != "" || s.Password.isSet()
+}
+
+// checkPair returns an error if source sets only one half of the username and password
+func checkPair(source string, username bool, password bool) error {
+	switch {
+	case username && !password:
+		return fmt.Errorf("username given without a password in %s", source)
+	case password && !username:
+		return fmt.Errorf("password given without a username in %s", source)
+	}
+
+	return nil
+}
+
+// overlay replaces the settings that are set in other. Username and password are replaced together if either is set.
+func (s *settings) overlay(other *settings) {
+	if other.Endpoint != "" {
+		s.Endpoint = other.Endpoint
+	}
+	if other.hasCredentials() {
+		s.Username = other.Username
+		s.Password = other.Password
+	}
+	if other.AccountId != "" {
+		s.AccountId = other.AccountId
+	}
+	if other.SkipSSL != nil {
+		s.SkipSSL = other.SkipSSL
+	}
+	if other.CABundle != "" {
+		s.CABundle = other.CABundle
+	}
+}
+
+func envSettings() (*settings, error) {
+	s := &settings{
+		Endpoint:  os.Getenv(EnvEndpoint),
+		Username:  os.Getenv(EnvUsername),
+		Password:  secret{Value: os.Getenv(EnvPassword)},
+		AccountId: os.Getenv(EnvAccountId),
+		CABundle:  os.Getenv(EnvCABundle),
+	}
+
+	if value := os.Getenv(EnvSkipSSL); value != "" {
+		skipSSL, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvSkipSSL, value, err)
+		}
+		s.SkipSSL = &skipSSL
+	}
+
+	return s, nil
+}
+
+// loadProfile reads a profile from the configuration file. Only a profile that was asked for
+// by name, or a file that was asked for by path, has to exist.
+func loadProfile(path string, name string) (*settings, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	namedProfile, namedFile := name != "", path != ""
+	if name == "" {
+		name = DefaultProfile
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &settings{}, nil
+		}
+		path = filepath.Join(home, ".storagegrid", "config.yaml")
+	}
+
+	data, err := os.ReadFile(expandHome(path))
+	if errors.Is(err, os.ErrNotExist) && !namedProfile && !namedFile {
+		return &settings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := config{}
+	err = yaml.Unmarshal(data, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		if namedProfile {
+			return nil, fmt.Errorf("profile %s not found in %s", name, path)
+		}
+		return &settings{}, nil
+	}
+
+	return &p, nil
+}
+
+// UnmarshalYAML accepts a plain string or a mapping with exactly one of value, env, file or command
+func (s *secret) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Value = node.Value
+		return nil
+	}
+
+	type plain secret
+	err := node.Decode((*plain)(s))
+	if err != nil {
+		return err
+	}
+
+	set := 0
+	for _, v := range []string{s.Value, s.Env, s.File, s.Command} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("line %d: exactly one of value, env, file or command must be set", node.Line)
+	}
+
+	return nil
+}
+
+func (s secret) isSet() bool {
+	return s != secret{}
+}
+
+// resolve returns the value of the secret. File contents and command output are trimmed of surrounding whitespace.
+func (s secret) resolve() (string, error) {
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return value, nil
+	case s.File != "":
+		data, err := os.ReadFile(expandHome(s.File))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	case s.Command != "":
+		shell, flag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+		cmd := exec.Command(shell, flag, s.Command) // #nosec G204 -- the command comes from the user's configuration
+		cmd.Stderr = os.Stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("command %q failed: %w", s.Command, err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	default:
+		return s.Value, nil
+	}
+}
+
+// loadCABundle returns the system roots extended by the PEM encoded certificates at path
+func loadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+
+	return pool, nil
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func TestResolveSettings_Credentials(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(config, []byte("profiles:\n  acme:\n    username: profile-user\n    password: profile-password\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		envUsername  string
+		envPassword  string
+		options      *models.Credentials
+		wantUsername string
+		wantPassword string
+		wantErr      string
+	}{
+		{name: "profile", wantUsername: "profile-user", wantPassword: "profile-password"},
+		{name: "env pair", envUsername: "env-user", envPassword: "env-password", wantUsername: "env-user", wantPassword: "env-password"},
+		{name: "env password only", envPassword: "env-password", wantErr: "password given without a username in the environment"},
+		{name: "env username only", envUsername: "env-user", wantErr: "username given without a password in the environment"},
+		{name: "option username only", envPassword: "env-password", options: &models.Credentials{Username: "option-user"}, wantErr: "username given without a password in the credentials"},
+		{name: "option pair", envUsername: "env-user", envPassword: "env-password", options: &models.Credentials{Username: "option-user", Password: "option-password"}, wantUsername: "option-user", wantPassword: "option-password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvUsername, tt.envUsername)
+			t.Setenv(EnvPassword, tt.envPassword)
+
+			c := &Client{}
+			FromEnv()(c)
+			FromProfile("acme")(c)
+			WithConfigFile(config)(c)
+			if tt.options != nil {
+				WithCredentials(tt.options)(c)
+			}
+
+			err := c.resolveSettings()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.credentials.Username != tt.wantUsername || c.credentials.Password != tt.wantPassword {
+				t.Errorf("credentials = %q/%q, want %q/%q", c.credentials.Username, c.credentials.Password, tt.wantUsername, tt.wantPassword)
+			}
+		})
+	}
+}
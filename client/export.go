@@ -0,0 +1,521 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// TenantExportVersion is the version of the export document written by Export and read by Import
+const TenantExportVersion = 1
+
+// Import actions
+const (
+	ImportCreate = "create"
+	ImportSkip   = "skip"
+)
+
+// TenantExport is a versioned snapshot of the configuration of a tenant account.
+// Passwords, secret access keys and endpoint secrets are never part of it.
+type TenantExport struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	// AccountId is the ID of the exported tenant account
+	AccountId string `json:"accountId,omitempty"`
+	// Account holds the name, capabilities, quota and policy of the account. Only GridClient.ExportTenant sets it,
+	// as tenant users cannot read the settings of their own account.
+	Account   *models.Tenant       `json:"account,omitempty"`
+	Groups    []models.TenantGroup `json:"groups"`
+	Users     []TenantExportUser   `json:"users"`
+	Buckets   []models.Bucket      `json:"buckets"`
+	Endpoints []models.Endpoint    `json:"endpoints"`
+}
+
+// TenantExportUser is an exported user with the metadata of its S3 access keys
+type TenantExportUser struct {
+	models.User
+	AccessKeys []models.S3AccessKey `json:"accessKeys,omitempty"`
+}
+
+// ImportOptions control how an export document is imported
+type ImportOptions struct {
+	// DryRun reports the actions of the import without changing the tenant. Group memberships are validated as in an import.
+	DryRun bool
+	// Rename maps names of the document to names on the target. Groups and users are addressed by unique name
+	// (e.g. "group/admins"), buckets and the tenant account by name, and platform services endpoints by URN.
+	// Names that are not mapped are kept.
+	Rename map[string]string
+	// Regions maps the bucket regions of the source grid to regions of the target grid
+	Regions map[string]string
+	// Passwords sets the passwords of created local users by their unique name on the target tenant
+	Passwords map[string]string
+	// CreateAccessKeys creates a new access key for every unexpired exported key of a created user, with the
+	// same expiry. Exported keys carry no secrets, so the new secrets are returned in ImportResult.AccessKeys.
+	CreateAccessKeys bool
+	// EndpointCredentials sets the credentials of created platform services endpoints by their URN on the target.
+	// Exported endpoints carry no secrets, so endpoints that authenticate cannot be created without them.
+	EndpointCredentials map[string]models.EndpointCredentials
+	// RootPassword is the password of the root user of a tenant account created by GridClient.ImportTenant
+	RootPassword string
+}
+
+// ImportAction is a resource created or skipped by an import
+type ImportAction struct {
+	// Kind is "account", "group", "user", "endpoint", "bucket" or "accessKey"
+	Kind string `json:"kind"`
+	// Name is the name on the target tenant
+	Name string `json:"name"`
+	// Source is the name in the document if it was renamed
+	Source string `json:"source,omitempty"`
+	// Action is ImportCreate, or ImportSkip for resources that already exist
+	Action string `json:"action"`
+}
+
+// ImportResult lists the actions of an import and the access keys it created by user unique name
+type ImportResult struct {
+	Actions    []ImportAction                  `json:"actions"`
+	AccessKeys map[string][]models.S3AccessKey `json:"accessKeys,omitempty"`
+	// Account is the tenant account created or found by GridClient.ImportTenant, without an ID in a dry run
+	Account *models.Tenant `json:"account,omitempty"`
+}
+
+// Export reads the groups with their policies, the users with the metadata of their access keys, the buckets
+// with their settings and the platform services endpoints. The identity source is not covered, and the settings
+// of the account are only exported by GridClient.ExportTenant.
+func (tc *TenantClient) Export(ctx context.Context) (*TenantExport, error) {
+	groups, err := tc.groups.Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	users, err := tc.users.Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	buckets, err := tc.bucket.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	endpoints, err := tc.endpoint.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	doc := &TenantExport{
+		Version:    TenantExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Groups:     *groups,
+		Users:      make([]TenantExportUser, 0, len(*users)),
+		Buckets:    *buckets,
+		Endpoints:  make([]models.Endpoint, 0, len(*endpoints)),
+	}
+
+	for _, endpoint := range *endpoints {
+		if endpoint.Credentials != nil {
+			credentials := *endpoint.Credentials
+			credentials.SecretAccessKey = nil
+			credentials.Password = nil
+			endpoint.Credentials = &credentials
+		}
+		endpoint.Error = nil
+		doc.Endpoints = append(doc.Endpoints, endpoint)
+	}
+
+	for _, user := range *users {
+		if doc.AccountId == "" && user.AccountId != nil {
+			doc.AccountId = *user.AccountId
+		}
+
+		exported := TenantExportUser{User: user}
+		if user.Id != nil {
+			keys, err := tc.s3AccessKeys.ListForUser(ctx, *user.Id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list access keys of user %s: %w", user.UniqueName, err)
+			}
+			for _, key := range *keys {
+				key.SecretAccessKey = nil
+				exported.AccessKeys = append(exported.AccessKeys, key)
+			}
+		}
+		doc.Users = append(doc.Users, exported)
+	}
+
+	return doc, nil
+}
+
+// Import creates the groups, users, platform services endpoints and buckets of an export document that do not
+// exist on the tenant yet. Existing resources are skipped, so an interrupted import can be repeated. The root user
+// is never imported, and the settings of the account are imported by GridClient.ImportTenant.
+// On error the result lists the actions completed so far.
+func (tc *TenantClient) Import(ctx context.Context, doc *TenantExport, opts *ImportOptions) (*ImportResult, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no export document")
+	}
+	if doc.Version != TenantExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", doc.Version)
+	}
+
+	options := ImportOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	rename := func(name string) string {
+		if renamed, ok := options.Rename[name]; ok {
+			return renamed
+		}
+		return name
+	}
+
+	result := &ImportResult{Actions: []ImportAction{}, AccessKeys: map[string][]models.S3AccessKey{}}
+	record := func(kind string, source string, name string, action string) {
+		entry := ImportAction{Kind: kind, Name: name, Action: action}
+		if source != name {
+			entry.Source = source
+		}
+		result.Actions = append(result.Actions, entry)
+	}
+
+	existingGroups, err := tc.groups.Iter().All(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list groups: %w", err)
+	}
+	existingUsers, err := tc.users.Iter().All(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list users: %w", err)
+	}
+	existingBuckets, err := tc.bucket.List(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list buckets: %w", err)
+	}
+	existingEndpoints, err := tc.endpoint.List(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	// groupIds maps the group IDs of the document to those of the target tenant
+	groupIds := map[string]string{}
+	targetGroups := map[string]string{}
+	for _, group := range *existingGroups {
+		targetGroups[group.UniqueName] = models.Deref(group.Id)
+	}
+
+	for _, group := range doc.Groups {
+		name := rename(group.UniqueName)
+		if id, ok := targetGroups[name]; ok {
+			groupIds[models.Deref(group.Id)] = id
+			record("group", group.UniqueName, name, ImportSkip)
+			continue
+		}
+
+		if options.DryRun {
+			// the group has no ID before it is created, memberships referencing it are valid nonetheless
+			groupIds[models.Deref(group.Id)] = ""
+		} else {
+			created, err := tc.groups.Create(ctx, &models.TenantGroup{
+				UniqueName:         name,
+				DisplayName:        group.DisplayName,
+				ManagementReadOnly: group.ManagementReadOnly,
+				Policies:           group.Policies,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to create group %s: %w", name, err)
+			}
+			groupIds[models.Deref(group.Id)] = models.Deref(created.Id)
+		}
+		record("group", group.UniqueName, name, ImportCreate)
+	}
+
+	targetUsers := map[string]bool{}
+	for _, user := range *existingUsers {
+		targetUsers[user.UniqueName] = true
+	}
+
+	for _, user := range doc.Users {
+		if user.UniqueName == models.TenantRootUsername {
+			continue
+		}
+
+		name := rename(user.UniqueName)
+		if targetUsers[name] {
+			record("user", user.UniqueName, name, ImportSkip)
+			continue
+		}
+		if !options.DryRun {
+			err = tc.importUser(ctx, &user, name, groupIds, &options, result, record)
+			if err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		_, err = targetMemberOf(&user, groupIds)
+		if err != nil {
+			return result, err
+		}
+		record("user", user.UniqueName, name, ImportCreate)
+		if options.CreateAccessKeys {
+			for _, key := range user.AccessKeys {
+				action := ImportCreate
+				if expired(&key) {
+					action = ImportSkip
+				}
+				record("accessKey", name, name, action)
+			}
+		}
+	}
+
+	targetEndpoints := map[string]bool{}
+	for _, endpoint := range *existingEndpoints {
+		targetEndpoints[endpoint.EndpointURN] = true
+	}
+
+	for _, endpoint := range doc.Endpoints {
+		urn := rename(endpoint.EndpointURN)
+		if targetEndpoints[urn] {
+			record("endpoint", endpoint.EndpointURN, urn, ImportSkip)
+			continue
+		}
+
+		if !options.DryRun {
+			var credentials *models.EndpointCredentials
+			if c, ok := options.EndpointCredentials[urn]; ok {
+				credentials = &c
+			}
+
+			_, err = tc.endpoint.Create(ctx, &models.Endpoint{
+				DisplayName: endpoint.DisplayName,
+				EndpointURI: endpoint.EndpointURI,
+				EndpointURN: urn,
+				AuthType:    endpoint.AuthType,
+				Credentials: credentials,
+				CaCert:      endpoint.CaCert,
+				InsecureTLS: endpoint.InsecureTLS,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to create endpoint %s: %w", urn, err)
+			}
+		}
+		record("endpoint", endpoint.EndpointURN, urn, ImportCreate)
+	}
+
+	targetBuckets := map[string]bool{}
+	for _, bucket := range *existingBuckets {
+		targetBuckets[bucket.Name] = true
+	}
+
+	for _, bucket := range doc.Buckets {
+		name := rename(bucket.Name)
+		if targetBuckets[name] {
+			record("bucket", bucket.Name, name, ImportSkip)
+			continue
+		}
+
+		if !options.DryRun {
+			region := bucket.Region
+			if mapped, ok := options.Regions[region]; ok {
+				region = mapped
+			}
+
+			_, err = tc.bucket.Create(ctx, &models.Bucket{
+				Name:             name,
+				Region:           region,
+				EnableVersioning: bucket.EnableVersioning,
+				S3ObjectLock:     bucket.S3ObjectLock,
+				Compliance:       bucket.Compliance,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to create bucket %s: %w", name, err)
+			}
+		}
+		record("bucket", bucket.Name, name, ImportCreate)
+	}
+
+	return result, nil
+}
+
+// targetMemberOf maps the groups of a user to the groups of the target tenant. Every group has to be part of the export.
+func targetMemberOf(user *TenantExportUser, groupIds map[string]string) ([]string, error) {
+	memberOf := []string{}
+	for _, id := range user.MemberOf {
+		targetId, ok := groupIds[id]
+		if !ok {
+			return nil, fmt.Errorf("user %s is member of group %s, which is not part of the export", user.UniqueName, id)
+		}
+		memberOf = append(memberOf, targetId)
+	}
+
+	return memberOf, nil
+}
+
+// importUser creates a user with its group memberships, password and access keys. Each action is recorded as soon as
+// it is done, so the result of a failed import lists the user even if its password or keys could not be set.
+func (tc *TenantClient) importUser(ctx context.Context, user *TenantExportUser, name string, groupIds map[string]string, options *ImportOptions, result *ImportResult, record func(kind string, source string, name string, action string)) error {
+	memberOf, err := targetMemberOf(user, groupIds)
+	if err != nil {
+		return err
+	}
+
+	created, err := tc.users.Create(ctx, &models.User{
+		UniqueName: name,
+		FullName:   user.FullName,
+		MemberOf:   memberOf,
+		Disable:    user.Disable,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user %s: %w", name, err)
+	}
+	id := models.Deref(created.Id)
+	record("user", user.UniqueName, name, ImportCreate)
+
+	if password, ok := options.Passwords[name]; ok {
+		err = tc.users.SetPassword(ctx, id, password)
+		if err != nil {
+			return fmt.Errorf("failed to set password of user %s: %w", name, err)
+		}
+	}
+
+	if !options.CreateAccessKeys {
+		return nil
+	}
+	for _, key := range user.AccessKeys {
+		if expired(&key) {
+			record("accessKey", name, name, ImportSkip)
+			continue
+		}
+
+		createdKey, err := tc.s3AccessKeys.CreateForUser(ctx, id, &models.S3AccessKey{DisplayName: key.DisplayName, Expires: key.Expires})
+		if err != nil {
+			return fmt.Errorf("failed to create access key of user %s: %w", name, err)
+		}
+		result.AccessKeys[name] = append(result.AccessKeys[name], *createdKey)
+		record("accessKey", name, name, ImportCreate)
+	}
+
+	return nil
+}
+
+// ExportTenant exports the tenant account signed in with tenantClient like TenantClient.Export, together with the
+// name, description, capabilities, quota and policy of the account, which only grid administrators can read.
+func (gc *GridClient) ExportTenant(ctx context.Context, tenantClient *TenantClient) (*TenantExport, error) {
+	current, err := tenantClient.current.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if current.AccountId == nil {
+		return nil, fmt.Errorf("tenant client is not signed in to a tenant account")
+	}
+
+	doc, err := tenantClient.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := gc.tenant.GetById(ctx, *current.AccountId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant %s: %w", *current.AccountId, err)
+	}
+
+	settings := *account
+	settings.Password = nil
+	doc.AccountId = account.Id
+	doc.Account = &settings
+
+	return doc, nil
+}
+
+// ImportTenant creates the tenant account of an export document written by ExportTenant with its description,
+// capabilities, quota and policy, and the root password of the options. An existing account of the same name is
+// skipped. Grid federation connections are specific to the source grid and not imported.
+//
+// Import the resources of the account afterwards with TenantClient.Import, signed in to ImportResult.Account:
+//
+//	result, err := targetGrid.ImportTenant(ctx, doc, &client.ImportOptions{RootPassword: password})
+//	if err != nil {
+//		return err
+//	}
+//	targetTenant, err := client.NewTenantClient(
+//		client.WithEndpoint(targetEndpoint),
+//		client.WithCredentials(&models.Credentials{Username: models.TenantRootUsername, Password: password, AccountId: &result.Account.Id}),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	_, err = targetTenant.Import(ctx, doc, &client.ImportOptions{})
+func (gc *GridClient) ImportTenant(ctx context.Context, doc *TenantExport, opts *ImportOptions) (*ImportResult, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no export document")
+	}
+	if doc.Version != TenantExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", doc.Version)
+	}
+	if doc.Account == nil || doc.Account.Name == nil {
+		return nil, fmt.Errorf("export document has no account settings, export it with GridClient.ExportTenant")
+	}
+
+	options := ImportOptions{}
+	if opts != nil {
+		options = *opts
+	}
+
+	source := *doc.Account.Name
+	name := source
+	if renamed, ok := options.Rename[source]; ok {
+		name = renamed
+	}
+
+	result := &ImportResult{Actions: []ImportAction{}, AccessKeys: map[string][]models.S3AccessKey{}}
+	action := ImportAction{Kind: "account", Name: name, Action: ImportCreate}
+	if source != name {
+		action.Source = source
+	}
+
+	tenants, err := gc.tenant.Iter().All(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	for i := range *tenants {
+		if models.Deref((*tenants)[i].Name) == name {
+			action.Action = ImportSkip
+			result.Actions = append(result.Actions, action)
+			result.Account = &(*tenants)[i]
+			return result, nil
+		}
+	}
+
+	tenant := &models.Tenant{Name: &name, Description: doc.Account.Description, Capabilities: doc.Account.Capabilities}
+	if doc.Account.Policy != nil {
+		policy := *doc.Account.Policy
+		policy.AllowedGridFederationConnections = nil
+		tenant.Policy = &policy
+	}
+
+	if options.DryRun {
+		result.Actions = append(result.Actions, action)
+		result.Account = tenant
+		return result, nil
+	}
+
+	if options.RootPassword == "" && (tenant.Policy == nil || !tenant.Policy.UseAccountIdentitySource) {
+		return result, fmt.Errorf("a root password is required to create tenant %s", name)
+	}
+	if options.RootPassword != "" {
+		tenant.Password = &options.RootPassword
+	}
+
+	created, err := gc.tenant.Create(ctx, tenant)
+	if err != nil {
+		return result, fmt.Errorf("failed to create tenant %s: %w", name, err)
+	}
+	result.Actions = append(result.Actions, action)
+	result.Account = created
+
+	return result, nil
+}
+
+func expired(key *models.S3AccessKey) bool {
+	return key.Expires != nil && !key.Expires.After(time.Now())
+}
@@ -0,0 +1,210 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/testing/fakegrid"
+)
+
+const rootPassword = "Export-Root-1"
+
+func newGridClient(t *testing.T, grid *fakegrid.Server) *client.GridClient {
+	t.Helper()
+
+	gridClient, err := client.NewGridClient(client.WithEndpoint(grid.URL), client.WithCredentials(grid.GridCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create grid client: %v", err)
+	}
+
+	return gridClient
+}
+
+func newTenantClient(t *testing.T, grid *fakegrid.Server, accountId string) *client.TenantClient {
+	t.Helper()
+
+	credentials := &models.Credentials{Username: models.TenantRootUsername, Password: rootPassword, AccountId: &accountId}
+	tenantClient, err := client.NewTenantClient(client.WithEndpoint(grid.URL), client.WithCredentials(credentials))
+	if err != nil {
+		t.Fatalf("Failed to create tenant client: %v", err)
+	}
+
+	return tenantClient
+}
+
+// newSourceTenant creates a tenant with platform services, a quota, a group, a user, a bucket and an endpoint
+func newSourceTenant(t *testing.T, grid *fakegrid.Server) (*client.GridClient, *client.TenantClient) {
+	t.Helper()
+
+	ctx := context.Background()
+	gridClient := newGridClient(t, grid)
+
+	name, password := "acme", rootPassword
+	quota := int64(1000000)
+	account, err := gridClient.Tenant().Create(ctx, &models.Tenant{
+		Name:         &name,
+		Capabilities: []string{"management", "s3"},
+		Policy:       &models.TenantPolicy{AllowPlatformServices: true, QuotaObjectBytes: &quota},
+		Password:     &password,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+
+	tenantClient := newTenantClient(t, grid, account.Id)
+	group, err := tenantClient.Groups().Create(ctx, &models.TenantGroup{UniqueName: "group/admins", DisplayName: "admins"})
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	_, err = tenantClient.Users().Create(ctx, &models.User{UniqueName: "user/alice", MemberOf: []string{*group.Id}})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	_, err = tenantClient.Bucket().Create(ctx, &models.Bucket{Name: "logs", Region: models.DefaultRegion})
+	if err != nil {
+		t.Fatalf("Failed to create bucket: %v", err)
+	}
+
+	authType, accessKeyId, secret := "s3", "AKIA-EXPORT", "export-secret"
+	_, err = tenantClient.Endpoints().Create(ctx, &models.Endpoint{
+		DisplayName: "mirror",
+		EndpointURI: "https://s3.example.com",
+		EndpointURN: "arn:aws:s3:::mirror",
+		AuthType:    &authType,
+		Credentials: &models.EndpointCredentials{AccessKeyId: &accessKeyId, SecretAccessKey: &secret},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+
+	return gridClient, tenantClient
+}
+
+func TestExportImport_OtherGrid(t *testing.T) {
+	ctx := context.Background()
+
+	source := fakegrid.New()
+	t.Cleanup(source.Close)
+	sourceGrid, sourceTenant := newSourceTenant(t, source)
+
+	doc, err := sourceGrid.ExportTenant(ctx, sourceTenant)
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	if doc.Account == nil || len(doc.Endpoints) != 1 {
+		t.Fatalf("Expected the account and one endpoint in the export, got %+v", doc)
+	}
+	if doc.Endpoints[0].Credentials.SecretAccessKey != nil {
+		t.Errorf("Expected no endpoint secret in the export")
+	}
+
+	target := fakegrid.New()
+	t.Cleanup(target.Close)
+	targetGrid := newGridClient(t, target)
+
+	accountResult, err := targetGrid.ImportTenant(ctx, doc, &client.ImportOptions{RootPassword: rootPassword})
+	if err != nil {
+		t.Fatalf("Failed to import tenant: %v", err)
+	}
+
+	secret := "target-secret"
+	accessKeyId := "AKIA-TARGET"
+	targetTenant := newTenantClient(t, target, accountResult.Account.Id)
+	result, err := targetTenant.Import(ctx, doc, &client.ImportOptions{
+		EndpointCredentials: map[string]models.EndpointCredentials{
+			"arn:aws:s3:::mirror": {AccessKeyId: &accessKeyId, SecretAccessKey: &secret},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to import resources: %v", err)
+	}
+
+	actions := []string{}
+	for _, action := range append(accountResult.Actions, result.Actions...) {
+		actions = append(actions, action.Action+" "+action.Kind+" "+action.Name)
+	}
+	expected := []string{"create account acme", "create group group/admins", "create user user/alice", "create endpoint arn:aws:s3:::mirror", "create bucket logs"}
+	if !slices.Equal(actions, expected) {
+		t.Errorf("Expected actions %v, got %v", expected, actions)
+	}
+
+	again, err := targetGrid.ExportTenant(ctx, targetTenant)
+	if err != nil {
+		t.Fatalf("Failed to export target: %v", err)
+	}
+	if !slices.Equal(again.Account.Capabilities, doc.Account.Capabilities) || !again.Account.Policy.AllowPlatformServices ||
+		*again.Account.Policy.QuotaObjectBytes != *doc.Account.Policy.QuotaObjectBytes {
+		t.Errorf("Expected account settings %+v, got %+v", doc.Account, again.Account)
+	}
+	if len(again.Endpoints) != 1 || again.Endpoints[0].EndpointURN != "arn:aws:s3:::mirror" {
+		t.Errorf("Expected the endpoint on the target, got %+v", again.Endpoints)
+	}
+}
+
+func TestImport_RecordsPartialUser(t *testing.T) {
+	ctx := context.Background()
+
+	source := fakegrid.New()
+	t.Cleanup(source.Close)
+	_, sourceTenant := newSourceTenant(t, source)
+
+	doc, err := sourceTenant.Export(ctx)
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	target := fakegrid.New()
+	t.Cleanup(target.Close)
+	account := target.AddTenant("acme", rootPassword)
+	target.InjectFault(fakegrid.Fault{Method: http.MethodPost, Path: "/org/users/*/change-password", Status: http.StatusBadRequest})
+
+	result, err := newTenantClient(t, target, account.Id).Import(ctx, doc, &client.ImportOptions{
+		Passwords: map[string]string{"user/alice": "Alice-Password-1"},
+	})
+	if err == nil {
+		t.Fatal("Expected the import to fail setting the password")
+	}
+
+	last := result.Actions[len(result.Actions)-1]
+	if last.Kind != "user" || last.Name != "user/alice" || last.Action != client.ImportCreate {
+		t.Errorf("Expected the created user as last action, got %+v", result.Actions)
+	}
+}
+
+func TestImport_DryRunValidatesMemberships(t *testing.T) {
+	ctx := context.Background()
+
+	source := fakegrid.New()
+	t.Cleanup(source.Close)
+	_, sourceTenant := newSourceTenant(t, source)
+
+	doc, err := sourceTenant.Export(ctx)
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	target := fakegrid.New()
+	t.Cleanup(target.Close)
+	account := target.AddTenant("acme", rootPassword)
+	targetTenant := newTenantClient(t, target, account.Id)
+
+	// memberships of groups created by the import are valid in a dry run as well
+	_, err = targetTenant.Import(ctx, doc, &client.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Expected the dry run to succeed, got %v", err)
+	}
+
+	// alice is a member of group/admins, which is missing from the document now
+	doc.Groups = nil
+	for _, dryRun := range []bool{true, false} {
+		_, err = targetTenant.Import(ctx, doc, &client.ImportOptions{DryRun: dryRun})
+		if err == nil || !strings.Contains(err.Error(), "which is not part of the export") {
+			t.Errorf("Expected the import with dry run %t to report the missing group, got %v", dryRun, err)
+		}
+	}
+}
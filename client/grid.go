@@ -14,11 +14,16 @@ type GridClient struct {
 	client *Client
 
 	// Services
-	tenant  services.TenantServiceInterface
-	health  services.HealthServiceInterface
-	region  services.RegionServiceInterface
-	haGroup services.HAGroupServiceInterface
-	gateway services.GatewayConfigServiceInterface
+	tenant     services.TenantServiceInterface
+	s3Keys     services.GridS3AccessKeyServiceInterface
+	health     services.HealthServiceInterface
+	region     services.GridRegionServiceInterface
+	gridConfig services.GridConfigServiceInterface
+	haGroup    services.HAGroupServiceInterface
+	gateway    services.GatewayConfigServiceInterface
+	traffic    services.TrafficClassificationServiceInterface
+	network    services.NetworkSecurityServiceInterface
+	current    services.CurrentUserServiceInterface
 }
 
 func NewGridClient(options ...ClientOption) (*GridClient, error) {
@@ -30,12 +35,17 @@ func NewGridClient(options ...ClientOption) (*GridClient, error) {
 	c.baseURL = c.baseURL.ResolveReference(&url.URL{Path: gridAPI})
 
 	return &GridClient{
-		client:  c,
-		tenant:  services.NewTenantService(c),
-		health:  services.NewHealthService(c),
-		region:  services.NewRegionGridService(c),
-		haGroup: services.NewHAGroupService(c),
-		gateway: services.NewGatewayConfigService(c),
+		client:     c,
+		tenant:     services.NewTenantService(c),
+		s3Keys:     services.NewGridS3AccessKeyService(c),
+		health:     services.NewHealthService(c),
+		region:     services.NewRegionGridService(c),
+		gridConfig: services.NewGridConfigService(c),
+		haGroup:    services.NewHAGroupService(c),
+		gateway:    services.NewGatewayConfigService(c),
+		traffic:    services.NewTrafficClassificationService(c),
+		network:    services.NewNetworkSecurityService(c),
+		current:    services.NewCurrentUserGridService(c),
 	}, nil
 }
 
@@ -45,14 +55,22 @@ func (gc *GridClient) Tenant() services.TenantServiceInterface {
 	return gc.tenant
 }
 
+func (gc *GridClient) S3AccessKeys() services.GridS3AccessKeyServiceInterface {
+	return gc.s3Keys
+}
+
 func (gc *GridClient) Health() services.HealthServiceInterface {
 	return gc.health
 }
 
-func (gc *GridClient) Region() services.RegionServiceInterface {
+func (gc *GridClient) Region() services.GridRegionServiceInterface {
 	return gc.region
 }
 
+func (gc *GridClient) GridConfig() services.GridConfigServiceInterface {
+	return gc.gridConfig
+}
+
 func (gc *GridClient) HAGroup() services.HAGroupServiceInterface {
 	return gc.haGroup
 }
@@ -60,3 +78,15 @@ func (gc *GridClient) HAGroup() services.HAGroupServiceInterface {
 func (gc *GridClient) Gateway() services.GatewayConfigServiceInterface {
 	return gc.gateway
 }
+
+func (gc *GridClient) TrafficClassification() services.TrafficClassificationServiceInterface {
+	return gc.traffic
+}
+
+func (gc *GridClient) NetworkSecurity() services.NetworkSecurityServiceInterface {
+	return gc.network
+}
+
+func (gc *GridClient) CurrentUser() services.CurrentUserServiceInterface {
+	return gc.current
+}
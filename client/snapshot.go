@@ -0,0 +1,374 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// GridSnapshotVersion is the version of the snapshot document written by Snapshot
+const GridSnapshotVersion = 1
+
+// Snapshot resource kinds
+const (
+	SnapshotKindHAGroup = "haGroup"
+	SnapshotKindGateway = "gateway"
+	SnapshotKindRegion  = "region"
+	SnapshotKindTenant  = "tenant"
+)
+
+// GridSnapshot is a sorted document of the configuration of a grid, meant to be stored and compared with other grids
+type GridSnapshot struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"takenAt"`
+	// HAGroups are sorted by name, their interfaces keep their order as the first one is the preferred primary
+	HAGroups []models.HAGroup `json:"haGroups"`
+	// Gateways are the load balancer endpoints sorted by name and port
+	Gateways []SnapshotGateway `json:"gateways"`
+	Regions  []string          `json:"regions"`
+	// Tenants are sorted by name with sorted capabilities
+	Tenants []models.Tenant `json:"tenants"`
+}
+
+// SnapshotGateway is a load balancer endpoint with its server configuration
+type SnapshotGateway struct {
+	models.GatewayConfig
+	ServerConfig *models.GWServerConfig `json:"serverConfig,omitempty"`
+}
+
+// CompareOptions control how snapshots are compared
+type CompareOptions struct {
+	// NodeIDMap maps the node IDs of the first snapshot to those of the second one. Unmapped node IDs are compared as they are.
+	NodeIDMap map[string]string
+	// IgnoreCertificates skips the certificate data of the gateway server configurations, which usually differs between grids
+	IgnoreCertificates bool
+}
+
+// SnapshotDifference is a resource or field that differs between two snapshots
+type SnapshotDifference struct {
+	// Kind is SnapshotKindHAGroup, SnapshotKindGateway, SnapshotKindRegion or SnapshotKindTenant
+	Kind string `json:"kind"`
+	// Name is the name of the resource, gateways without a name are named by port (e.g. ":10443"). Resources sharing
+	// a name within a snapshot are numbered from the second one on, e.g. "acme#2".
+	Name string `json:"name"`
+	// Path is the JSON path of the differing field, empty if the resource only exists in one snapshot
+	Path string `json:"path,omitempty"`
+	// A is the value in the first snapshot, nil if it is not set
+	A interface{} `json:"a"`
+	// B is the value in the second snapshot, nil if it is not set
+	B interface{} `json:"b"`
+}
+
+// Snapshot reads the HA groups, the load balancer endpoints with their server configurations, the regions and the
+// tenant accounts with their policies. Every list is sorted so that snapshots of the same configuration are equal.
+func (gc *GridClient) Snapshot(ctx context.Context) (*GridSnapshot, error) {
+	haGroups, err := gc.haGroup.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HA groups: %w", err)
+	}
+
+	gateways, err := gc.gateway.ListGatewayConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancer endpoints: %w", err)
+	}
+
+	regions, err := gc.region.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+
+	tenants, err := gc.tenant.Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	snapshot := &GridSnapshot{
+		Version:  GridSnapshotVersion,
+		TakenAt:  time.Now().UTC(),
+		HAGroups: *haGroups,
+		Gateways: make([]SnapshotGateway, 0, len(*gateways)),
+		Regions:  slices.Sorted(slices.Values(*regions)),
+		Tenants:  *tenants,
+	}
+
+	for _, gateway := range *gateways {
+		serverConfig, err := gc.gateway.GetGatewayServerConfig(ctx, gateway.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get server configuration of load balancer endpoint %s: %w", gatewayName(&gateway), err)
+		}
+		snapshot.Gateways = append(snapshot.Gateways, SnapshotGateway{GatewayConfig: gateway, ServerConfig: serverConfig})
+	}
+
+	sort.SliceStable(snapshot.HAGroups, func(i, j int) bool {
+		return models.Deref(snapshot.HAGroups[i].Name) < models.Deref(snapshot.HAGroups[j].Name)
+	})
+	sort.SliceStable(snapshot.Gateways, func(i, j int) bool {
+		a, b := &snapshot.Gateways[i].GatewayConfig, &snapshot.Gateways[j].GatewayConfig
+		if models.Deref(a.DisplayName) != models.Deref(b.DisplayName) {
+			return models.Deref(a.DisplayName) < models.Deref(b.DisplayName)
+		}
+		return models.Deref(a.Port) < models.Deref(b.Port)
+	})
+	for i := range snapshot.Tenants {
+		snapshot.Tenants[i].Capabilities = models.SortedCopy(snapshot.Tenants[i].Capabilities)
+	}
+	sort.SliceStable(snapshot.Tenants, func(i, j int) bool {
+		a, b := &snapshot.Tenants[i], &snapshot.Tenants[j]
+		if models.Deref(a.Name) != models.Deref(b.Name) {
+			return models.Deref(a.Name) < models.Deref(b.Name)
+		}
+		return a.Id < b.Id
+	})
+
+	return snapshot, nil
+}
+
+// CompareSnapshots reports the differences between two snapshots, e.g. of a production and a DR grid.
+//
+// Resources are matched by name. Resources sharing a name within a snapshot are matched by their order, as sorted by
+// Snapshot, and reported with an ordinal from the second one on, e.g. "acme#2". IDs generated by the grids are ignored: HA groups referenced by load balancer
+// endpoints are compared by name, tenant accounts referenced by endpoints and server configurations by tenant
+// name, and node IDs of a are translated with opts.NodeIDMap. The result lists HA groups, load balancer endpoints,
+// regions and tenants, each sorted by name and path.
+func CompareSnapshots(a *GridSnapshot, b *GridSnapshot, opts *CompareOptions) []SnapshotDifference {
+	options := CompareOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if a == nil {
+		a = &GridSnapshot{}
+	}
+	if b == nil {
+		b = &GridSnapshot{}
+	}
+
+	differences := []SnapshotDifference{}
+	add := func(kind string, name string, desired interface{}, actual interface{}) {
+		for _, change := range compareValues(desired, actual) {
+			differences = append(differences, SnapshotDifference{Kind: kind, Name: name, Path: change.Path, A: change.Desired, B: change.Actual})
+		}
+	}
+
+	haGroupsA, haGroupsB := map[string]interface{}{}, map[string]interface{}{}
+	countsA, countsB := map[string]int{}, map[string]int{}
+	for _, group := range a.HAGroups {
+		group := normalizeHAGroup(group, options.NodeIDMap)
+		haGroupsA[ordinalName(countsA, models.Deref(group.Name))] = &group
+	}
+	for _, group := range b.HAGroups {
+		group := normalizeHAGroup(group, nil)
+		haGroupsB[ordinalName(countsB, models.Deref(group.Name))] = &group
+	}
+	compareResources(SnapshotKindHAGroup, haGroupsA, haGroupsB, add)
+
+	gatewaysA, gatewaysB := map[string]interface{}{}, map[string]interface{}{}
+	countsA, countsB = map[string]int{}, map[string]int{}
+	for _, gateway := range a.Gateways {
+		gateway := normalizeGateway(gateway, a, options.NodeIDMap, options.IgnoreCertificates)
+		gatewaysA[ordinalName(countsA, gatewayName(&gateway.GatewayConfig))] = &gateway
+	}
+	for _, gateway := range b.Gateways {
+		gateway := normalizeGateway(gateway, b, nil, options.IgnoreCertificates)
+		gatewaysB[ordinalName(countsB, gatewayName(&gateway.GatewayConfig))] = &gateway
+	}
+	compareResources(SnapshotKindGateway, gatewaysA, gatewaysB, add)
+
+	regionsA, regionsB := map[string]interface{}{}, map[string]interface{}{}
+	for _, region := range a.Regions {
+		regionsA[region] = region
+	}
+	for _, region := range b.Regions {
+		regionsB[region] = region
+	}
+	compareResources(SnapshotKindRegion, regionsA, regionsB, add)
+
+	tenantsA, tenantsB := map[string]interface{}{}, map[string]interface{}{}
+	countsA, countsB = map[string]int{}, map[string]int{}
+	for _, tenant := range a.Tenants {
+		tenant := normalizeTenant(tenant)
+		tenantsA[ordinalName(countsA, models.Deref(tenant.Name))] = &tenant
+	}
+	for _, tenant := range b.Tenants {
+		tenant := normalizeTenant(tenant)
+		tenantsB[ordinalName(countsB, models.Deref(tenant.Name))] = &tenant
+	}
+	compareResources(SnapshotKindTenant, tenantsA, tenantsB, add)
+
+	return differences
+}
+
+// ordinalName returns the name of the next resource called name, numbering the resources that share a name
+// from the second one on, so that they are matched by order instead of overwriting each other
+func ordinalName(counts map[string]int, name string) string {
+	counts[name]++
+	if counts[name] == 1 {
+		return name
+	}
+
+	return name + "#" + strconv.Itoa(counts[name])
+}
+
+// compareResources compares the resources of both snapshots by name in sorted order, resources missing from
+// one snapshot are reported as a whole
+func compareResources(kind string, a map[string]interface{}, b map[string]interface{}, add func(string, string, interface{}, interface{})) {
+	names := []string{}
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		add(kind, name, a[name], b[name])
+	}
+}
+
+// compareValues diffs in both directions, as models.Diff only compares the fields that are set in its first argument
+func compareValues(a interface{}, b interface{}) []models.FieldChange {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return nil
+		}
+		return []models.FieldChange{{Desired: a, Actual: b}}
+	}
+
+	changes := models.Diff(a, b)
+	seen := map[string]bool{}
+	for _, change := range changes {
+		seen[change.Path] = true
+	}
+	for _, change := range models.Diff(b, a) {
+		if !seen[change.Path] {
+			changes = append(changes, models.FieldChange{Path: change.Path, Desired: change.Actual, Actual: change.Desired})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// normalizeHAGroup copies the interfaces of an HA group and maps their node IDs
+func normalizeHAGroup(group models.HAGroup, nodeIDs map[string]string) models.HAGroup {
+	if group.Interfaces != nil {
+		interfaces := make([]models.Interfaces, 0, len(*group.Interfaces))
+		for _, iface := range *group.Interfaces {
+			iface.NodeID = mapNodeID(iface.NodeID, nodeIDs)
+			interfaces = append(interfaces, iface)
+		}
+		group.Interfaces = &interfaces
+	}
+
+	return group
+}
+
+// normalizeGateway replaces the HA group and tenant account IDs of a load balancer endpoint with names and maps its node IDs
+func normalizeGateway(gateway SnapshotGateway, snapshot *GridSnapshot, nodeIDs map[string]string, ignoreCertificates bool) SnapshotGateway {
+	haGroups := map[string]string{}
+	for _, group := range snapshot.HAGroups {
+		haGroups[group.Id] = models.Deref(group.Name)
+	}
+	accounts := map[string]string{}
+	for _, tenant := range snapshot.Tenants {
+		accounts[tenant.Id] = models.Deref(tenant.Name)
+	}
+
+	if gateway.AccountID != nil {
+		gateway.AccountID = mapValue(gateway.AccountID, accounts)
+	}
+
+	if gateway.PinTargets != nil {
+		pinTargets := *gateway.PinTargets
+		if pinTargets.HaGroups != nil {
+			names := make([]string, 0, len(*pinTargets.HaGroups))
+			for _, id := range *pinTargets.HaGroups {
+				names = append(names, *mapValue(&id, haGroups))
+			}
+			slices.Sort(names)
+			pinTargets.HaGroups = &names
+		}
+		if pinTargets.NodeInterfaces != nil {
+			nodeInterfaces := make([]models.NodeInterfaces, 0, len(*pinTargets.NodeInterfaces))
+			for _, iface := range *pinTargets.NodeInterfaces {
+				iface.NodeID = mapNodeID(iface.NodeID, nodeIDs)
+				nodeInterfaces = append(nodeInterfaces, iface)
+			}
+			sort.SliceStable(nodeInterfaces, func(i, j int) bool {
+				a, b := nodeInterfaces[i], nodeInterfaces[j]
+				if models.Deref(a.NodeID) != models.Deref(b.NodeID) {
+					return models.Deref(a.NodeID) < models.Deref(b.NodeID)
+				}
+				return models.Deref(a.Interface) < models.Deref(b.Interface)
+			})
+			pinTargets.NodeInterfaces = &nodeInterfaces
+		}
+		if pinTargets.NodeTypes != nil {
+			nodeTypes := models.SortedCopy(*pinTargets.NodeTypes)
+			pinTargets.NodeTypes = &nodeTypes
+		}
+		gateway.PinTargets = &pinTargets
+	}
+
+	if gateway.ServerConfig != nil {
+		serverConfig := *gateway.ServerConfig
+		if serverConfig.AccountRestrictions != nil {
+			restrictions := make([]string, 0, len(*serverConfig.AccountRestrictions))
+			for _, id := range *serverConfig.AccountRestrictions {
+				restrictions = append(restrictions, *mapValue(&id, accounts))
+			}
+			slices.Sort(restrictions)
+			serverConfig.AccountRestrictions = &restrictions
+		}
+		if ignoreCertificates {
+			serverConfig.PlaintextCertData = nil
+		}
+		gateway.ServerConfig = &serverConfig
+	}
+
+	return gateway
+}
+
+// normalizeTenant sorts the capabilities and drops the write-only root password
+func normalizeTenant(tenant models.Tenant) models.Tenant {
+	tenant.Capabilities = models.SortedCopy(tenant.Capabilities)
+	tenant.Password = nil
+
+	return tenant
+}
+
+func mapNodeID(id *string, nodeIDs map[string]string) *string {
+	if id == nil {
+		return nil
+	}
+
+	return mapValue(id, nodeIDs)
+}
+
+// mapValue returns a pointer to the mapped value, or to a copy of the value if it is not mapped
+func mapValue(value *string, mapping map[string]string) *string {
+	mapped, ok := mapping[*value]
+	if !ok {
+		mapped = *value
+	}
+
+	return &mapped
+}
+
+// gatewayName returns the display name of a load balancer endpoint, or its port if it has no name
+func gatewayName(gateway *models.GatewayConfig) string {
+	if gateway.DisplayName != nil && *gateway.DisplayName != "" {
+		return *gateway.DisplayName
+	}
+
+	return ":" + strconv.Itoa(models.Deref(gateway.Port))
+}
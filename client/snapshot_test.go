@@ -0,0 +1,126 @@
+package client_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func haGroup(id string, name string, nodeId string) models.HAGroup {
+	return models.HAGroup{
+		Id:         id,
+		Name:       ptr(name),
+		VirtualIps: &[]string{"192.0.2.10"},
+		Interfaces: &[]models.Interfaces{{Interface: ptr("eth2"), NodeID: ptr(nodeId)}},
+	}
+}
+
+func tenant(id string, name string, quota int64) models.Tenant {
+	return models.Tenant{Id: id, Name: ptr(name), Capabilities: []string{"management", "s3"}, Policy: &models.TenantPolicy{QuotaObjectBytes: ptr(quota)}}
+}
+
+func gateway(id string, name string, haGroupId string, accountId string, cert string) client.SnapshotGateway {
+	return client.SnapshotGateway{
+		GatewayConfig: models.GatewayConfig{
+			Id:          id,
+			DisplayName: ptr(name),
+			Port:        ptr(10443),
+			AccountID:   ptr(accountId),
+			PinTargets:  &models.PinTargets{HaGroups: &[]string{haGroupId}},
+		},
+		ServerConfig: &models.GWServerConfig{
+			AccountRestrictions: &[]string{accountId},
+			PlaintextCertData:   &models.PlaintextCertData{ServerCertificateEncoded: ptr(cert)},
+		},
+	}
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	// the same configuration on two grids with different IDs, node IDs and certificates
+	prod := &client.GridSnapshot{
+		HAGroups: []models.HAGroup{haGroup("prod-ha", "ha1", "prod-node")},
+		Gateways: []client.SnapshotGateway{gateway("prod-gw", "s3", "prod-ha", "prod-acme", "prod-cert")},
+		Regions:  []string{"us-east-1"},
+		Tenants:  []models.Tenant{tenant("prod-acme", "acme", 100)},
+	}
+	dr := &client.GridSnapshot{
+		HAGroups: []models.HAGroup{haGroup("dr-ha", "ha1", "dr-node")},
+		Gateways: []client.SnapshotGateway{gateway("dr-gw", "s3", "dr-ha", "dr-acme", "dr-cert")},
+		Regions:  []string{"us-east-1"},
+		Tenants:  []models.Tenant{tenant("dr-acme", "acme", 100)},
+	}
+
+	tests := []struct {
+		name     string
+		options  *client.CompareOptions
+		expected []client.SnapshotDifference
+	}{
+		{
+			name:    "node IDs and certificates differ",
+			options: nil,
+			expected: []client.SnapshotDifference{
+				{Kind: client.SnapshotKindHAGroup, Name: "ha1", Path: "interfaces[0].nodeId", A: "prod-node", B: "dr-node"},
+				{Kind: client.SnapshotKindGateway, Name: "s3", Path: "serverConfig.plaintextCertData.serverCertificateEncoded", A: "prod-cert", B: "dr-cert"},
+			},
+		},
+		{
+			name:    "mapped node IDs",
+			options: &client.CompareOptions{NodeIDMap: map[string]string{"prod-node": "dr-node"}},
+			expected: []client.SnapshotDifference{
+				{Kind: client.SnapshotKindGateway, Name: "s3", Path: "serverConfig.plaintextCertData.serverCertificateEncoded", A: "prod-cert", B: "dr-cert"},
+			},
+		},
+		{
+			name:     "mapped node IDs and ignored certificates",
+			options:  &client.CompareOptions{NodeIDMap: map[string]string{"prod-node": "dr-node"}, IgnoreCertificates: true},
+			expected: []client.SnapshotDifference{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			differences := client.CompareSnapshots(prod, dr, tt.options)
+			if !reflect.DeepEqual(differences, tt.expected) {
+				t.Errorf("CompareSnapshots() = %+v, want %+v", differences, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareSnapshots_MissingResources(t *testing.T) {
+	a := &client.GridSnapshot{Regions: []string{"us-east-1", "eu-central-1"}, Tenants: []models.Tenant{tenant("1", "acme", 100)}}
+	b := &client.GridSnapshot{Regions: []string{"us-east-1"}}
+
+	differences := client.CompareSnapshots(a, b, nil)
+	if len(differences) != 2 {
+		t.Fatalf("Expected 2 differences, got %+v", differences)
+	}
+	if region := differences[0]; region.Kind != client.SnapshotKindRegion || region.Name != "eu-central-1" || region.Path != "" || region.B != nil {
+		t.Errorf("Expected the missing region, got %+v", region)
+	}
+	if tenant := differences[1]; tenant.Kind != client.SnapshotKindTenant || tenant.Name != "acme" || tenant.Path != "" || tenant.B != nil {
+		t.Errorf("Expected the missing tenant, got %+v", tenant)
+	}
+}
+
+func TestCompareSnapshots_DuplicateNames(t *testing.T) {
+	a := &client.GridSnapshot{Tenants: []models.Tenant{tenant("1", "acme", 100), tenant("2", "acme", 200), tenant("3", "acme", 300)}}
+	b := &client.GridSnapshot{Tenants: []models.Tenant{tenant("4", "acme", 100), tenant("5", "acme", 250)}}
+
+	differences := client.CompareSnapshots(a, b, nil)
+
+	names := []string{}
+	for _, difference := range differences {
+		names = append(names, difference.Name+" "+difference.Path)
+	}
+	expected := []string{"acme#2 policy.quotaObjectBytes", "acme#3 "}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected differences %v, got %v", expected, names)
+	}
+}
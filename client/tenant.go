@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/yehlo/storagegrid-sdk-go/services"
@@ -19,6 +20,8 @@ type TenantClient struct {
 	users        services.TenantUserServiceInterface
 	groups       services.TenantGroupServiceInterface
 	region       services.RegionServiceInterface
+	current      services.CurrentUserServiceInterface
+	endpoint     services.EndpointServiceInterface
 }
 
 func NewTenantClient(options ...ClientOption) (*TenantClient, error) {
@@ -36,6 +39,8 @@ func NewTenantClient(options ...ClientOption) (*TenantClient, error) {
 		users:        services.NewTenantUserService(c),
 		groups:       services.NewTenantGroupService(c),
 		region:       services.NewRegionTenantService(c),
+		current:      services.NewCurrentUserTenantService(c),
+		endpoint:     services.NewEndpointService(c),
 	}, nil
 }
 
@@ -60,3 +65,16 @@ func (tc *TenantClient) Groups() services.TenantGroupServiceInterface {
 func (tc *TenantClient) Region() services.RegionServiceInterface {
 	return tc.region
 }
+
+func (tc *TenantClient) CurrentUser() services.CurrentUserServiceInterface {
+	return tc.current
+}
+
+func (tc *TenantClient) Endpoints() services.EndpointServiceInterface {
+	return tc.endpoint
+}
+
+// AuditS3AccessKeys reports the expiry status of every S3 access key of the tenant and optionally deletes expired keys.
+func (tc *TenantClient) AuditS3AccessKeys(ctx context.Context, opts *services.S3AccessKeyAuditOptions) (*services.S3AccessKeyAuditReport, error) {
+	return services.AuditS3AccessKeys(ctx, tc.users, tc.s3AccessKeys, opts)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const defaultOutput = outputTable
+
+// app holds the global options and output of a single invocation
+type app struct {
+	options options
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// options are the global flags, accepted before the resource and after the command
+type options struct {
+	config    string
+	profile   string
+	endpoint  string
+	username  string
+	password  string
+	accountId string
+	skipSSL   bool
+	caBundle  string
+	output    string
+}
+
+// register adds the global flags to fs, keeping values parsed before
+func (o *options) register(fs *flag.FlagSet) {
+	if o.output == "" {
+		o.output = defaultOutput
+	}
+
+	fs.StringVar(&o.config, "config", o.config, "configuration file (default ~/.storagegrid/config.yaml, env STORAGEGRID_CONFIG_FILE)")
+	fs.StringVar(&o.profile, "profile", o.profile, "named profile of the configuration file (env STORAGEGRID_PROFILE)")
+	fs.StringVar(&o.endpoint, "endpoint", o.endpoint, "grid endpoint (env STORAGEGRID_ENDPOINT)")
+	fs.StringVar(&o.username, "username", o.username, "username (env STORAGEGRID_USERNAME)")
+	fs.StringVar(&o.password, "password", o.password, "password, discouraged since it shows up in shell history and process listings, prefer env STORAGEGRID_PASSWORD or a profile")
+	fs.StringVar(&o.accountId, "account-id", o.accountId, "tenant account ID for tenant resources (env STORAGEGRID_ACCOUNT_ID)")
+	fs.BoolVar(&o.skipSSL, "skip-ssl", o.skipSSL, "skip TLS certificate verification (env STORAGEGRID_SKIP_SSL)")
+	fs.StringVar(&o.caBundle, "ca-bundle", o.caBundle, "PEM file of additional trusted CA certificates (env STORAGEGRID_CA_BUNDLE)")
+	fs.StringVar(&o.output, "o", o.output, "output format: table, json or yaml")
+}
+
+// clientOptions resolves the connection settings. Flags take precedence over the environment, which takes precedence over the profile.
+// -username and -password are only used together, so a password of the environment or profile never signs in the user of a flag.
+func (a *app) clientOptions() ([]client.ClientOption, error) {
+	options := []client.ClientOption{
+		client.FromEnv(),
+		client.FromProfile(a.options.profile),
+	}
+	if a.options.config != "" {
+		options = append(options, client.WithConfigFile(a.options.config))
+	}
+	if a.options.endpoint != "" {
+		options = append(options, client.WithEndpoint(a.options.endpoint))
+	}
+	if a.options.caBundle != "" {
+		options = append(options, client.WithCABundle(a.options.caBundle))
+	}
+	if a.options.skipSSL {
+		options = append(options, client.WithSkipSSL())
+	}
+
+	switch {
+	case a.options.username != "" && a.options.password == "":
+		return nil, errors.New("-username given without a password, pass -password or set both in the environment or a profile")
+	case a.options.password != "" && a.options.username == "":
+		return nil, errors.New("-password given without a username, pass -username or set both in the environment or a profile")
+	}
+
+	credentials := &models.Credentials{Username: a.options.username, Password: a.options.password}
+	if a.options.accountId != "" {
+		credentials.AccountId = &a.options.accountId
+	}
+	if *credentials != (models.Credentials{}) {
+		options = append(options, client.WithCredentials(credentials))
+	}
+
+	return options, nil
+}
+
+func (a *app) gridClient() (*client.GridClient, error) {
+	options, err := a.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewGridClient(options...)
+}
+
+func (a *app) tenantClient() (*client.TenantClient, error) {
+	options, err := a.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewTenantClient(options...)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/testing/fakegrid"
+)
+
+func TestCredentialFlags(t *testing.T) {
+	grid := fakegrid.New()
+	defer grid.Close()
+	credentials := grid.GridCredentials()
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	profile := fmt.Sprintf("profiles:\n  acme:\n    endpoint: %s\n    username: profile-user\n    password: profile-password\n", grid.URL)
+	err := os.WriteFile(config, []byte(profile), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		envPassword string
+		args        []string
+		wantErr     string
+	}{
+		{
+			name:        "username flag with env password",
+			envPassword: credentials.Password,
+			args:        []string{"-endpoint", grid.URL, "-username", credentials.Username},
+			wantErr:     "-username given without a password",
+		},
+		{
+			name:    "password flag only",
+			args:    []string{"-endpoint", grid.URL, "-password", credentials.Password},
+			wantErr: "-password given without a username",
+		},
+		{
+			name: "flags",
+			args: []string{"-endpoint", grid.URL, "-username", credentials.Username, "-password", credentials.Password},
+		},
+		{
+			name:    "profile with username flag",
+			args:    []string{"-config", config, "-profile", "acme", "-username", credentials.Username},
+			wantErr: "-username given without a password",
+		},
+		{
+			name: "profile with username and password flags",
+			args: []string{"-config", config, "-profile", "acme", "-username", credentials.Username, "-password", credentials.Password},
+		},
+		{
+			// the profile pair is used as a whole and does not match the grid
+			name:    "profile",
+			args:    []string{"-config", config, "-profile", "acme"},
+			wantErr: "401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{client.EnvEndpoint, client.EnvUsername, client.EnvAccountId, client.EnvProfile, client.EnvConfigFile} {
+				t.Setenv(name, "")
+			}
+			t.Setenv(client.EnvPassword, tt.envPassword)
+
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			err := run(context.Background(), append(tt.args, "tenant", "list"), stdout, stderr)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected the flags to sign in, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func tenantResource() resource {
+	return resource{
+		name:    "tenant",
+		summary: "tenant accounts of the grid",
+		commands: []command{
+			{name: "list", summary: "list tenant accounts", run: tenantList},
+			{name: "get", args: "<id>", summary: "show a tenant account", run: tenantGet},
+			{name: "create", args: "-name <name> [-password-file <file>]", summary: "create a tenant account, reads the root password from stdin or the file", run: tenantCreate},
+			{name: "delete", args: "<id>", summary: "delete a tenant account, asks for confirmation unless -yes", run: tenantDelete},
+			{name: "usage", args: "<id>", summary: "show the storage usage of a tenant account", run: tenantUsage},
+		},
+	}
+}
+
+func tenantTable(tenants ...models.Tenant) table {
+	t := table{header: []string{"ID", "NAME", "CAPABILITIES", "QUOTA"}}
+	for _, tenant := range tenants {
+		quota := ""
+		if tenant.Policy != nil {
+			quota = bytesStr(tenant.Policy.QuotaObjectBytes)
+		}
+		t.add(tenant.Id, str(tenant.Name), strings.Join(tenant.Capabilities, ","), quota)
+	}
+
+	return t
+}
+
+func tenantList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tenant list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	tenants, err := gridClient.Tenant().Iter().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(tenants, func() table { return tenantTable(*tenants...) })
+}
+
+func tenantGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tenant get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	tenant, err := gridClient.Tenant().GetById(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(tenant, func() table { return tenantTable(*tenant) })
+}
+
+func tenantCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tenant create")
+	name := fs.String("name", "", "name of the tenant account")
+	description := fs.String("description", "", "description of the tenant account")
+	capabilities := fs.String("capabilities", "management,s3", "comma separated capabilities")
+	passwordFile := fs.String("password-file", "", "file with the password of the root user, read from stdin if not given")
+	quota := fs.Int64("quota", 0, "quota in bytes, 0 for unlimited")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *name == "" {
+		return fmt.Errorf("%w: -name is required", errUsage)
+	}
+
+	password, err := a.readSecret(*passwordFile, "Password of the root user")
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	tenant := &models.Tenant{
+		Name:         name,
+		Capabilities: splitList(*capabilities),
+		Password:     &password,
+	}
+	if *description != "" {
+		tenant.Description = description
+	}
+	if *quota > 0 {
+		tenant.Policy = &models.TenantPolicy{QuotaObjectBytes: quota}
+	}
+
+	created, err := gridClient.Tenant().Create(ctx, tenant)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return tenantTable(*created) })
+}
+
+func tenantDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tenant delete")
+	yes := fs.Bool("yes", false, "delete the tenant account without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete tenant "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	err = gridClient.Tenant().Delete(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted tenant %s", positional[0])
+	return nil
+}
+
+func tenantUsage(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("tenant usage")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	usage, err := gridClient.Tenant().GetUsage(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(usage, func() table { return usageTable(usage) })
+}
+
+func usageTable(usage *models.TenantUsage) table {
+	t := table{header: []string{"BUCKET", "REGION", "OBJECTS", "DATA"}}
+	for _, bucket := range usage.Buckets {
+		if bucket != nil {
+			t.add(str(bucket.Name), str(bucket.Region), intStr(bucket.ObjectCount), bytesStr(bucket.DataBytes))
+		}
+	}
+	t.add("TOTAL", "", intStr(usage.ObjectCount), bytesStr(usage.DataBytes))
+
+	return t
+}
+
+func haGroupResource() resource {
+	return resource{
+		name:    "hagroup",
+		summary: "high availability groups of the grid",
+		commands: []command{
+			{name: "list", summary: "list HA groups", run: haGroupList},
+			{name: "get", args: "<id>", summary: "show an HA group", run: haGroupGet},
+			{name: "create", args: "-f <file>", summary: "create an HA group from a JSON or YAML file", run: haGroupCreate},
+			{name: "delete", args: "<id>", summary: "delete an HA group, asks for confirmation unless -yes", run: haGroupDelete},
+		},
+	}
+}
+
+func haGroupTable(groups ...models.HAGroup) table {
+	t := table{header: []string{"ID", "NAME", "VIRTUAL IPS", "INTERFACES"}}
+	for _, group := range groups {
+		virtualIps := ""
+		if group.VirtualIps != nil {
+			virtualIps = strings.Join(*group.VirtualIps, ",")
+		}
+
+		interfaces := []string{}
+		if group.Interfaces != nil {
+			for _, iface := range *group.Interfaces {
+				interfaces = append(interfaces, str(iface.NodeID)+"/"+str(iface.Interface))
+			}
+		}
+		t.add(group.Id, str(group.Name), virtualIps, strings.Join(interfaces, ","))
+	}
+
+	return t
+}
+
+func haGroupList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("hagroup list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	groups, err := gridClient.HAGroup().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(groups, func() table { return haGroupTable(*groups...) })
+}
+
+func haGroupGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("hagroup get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	group, err := gridClient.HAGroup().GetById(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(group, func() table { return haGroupTable(*group) })
+}
+
+func haGroupCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("hagroup create")
+	file := fs.String("f", "", "JSON or YAML file with the HA group, - for stdin")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	group := &models.HAGroup{}
+	err := readSpec(*file, group)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	created, err := gridClient.HAGroup().Create(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return haGroupTable(*created) })
+}
+
+func haGroupDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("hagroup delete")
+	yes := fs.Bool("yes", false, "delete the HA group without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete HA group "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	err = gridClient.HAGroup().Delete(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted HA group %s", positional[0])
+	return nil
+}
+
+func gatewayResource() resource {
+	return resource{
+		name:    "gateway",
+		summary: "load balancer endpoints of the grid",
+		commands: []command{
+			{name: "list", summary: "list load balancer endpoints", run: gatewayList},
+			{name: "get", args: "<id>", summary: "show a load balancer endpoint", run: gatewayGet},
+			{name: "create", args: "-f <file>", summary: "create a load balancer endpoint from a JSON or YAML file", run: gatewayCreate},
+			{name: "delete", args: "<id>", summary: "delete a load balancer endpoint, asks for confirmation unless -yes", run: gatewayDelete},
+			{name: "server-config", args: "<id>", summary: "show the server configuration of a load balancer endpoint", run: gatewayServerConfig},
+		},
+	}
+}
+
+func gatewayTable(gateways ...models.GatewayConfig) table {
+	t := table{header: []string{"ID", "NAME", "PORT", "SECURE"}}
+	for _, gateway := range gateways {
+		t.add(gateway.Id, str(gateway.DisplayName), intStr(gateway.Port), boolStr(gateway.Secure))
+	}
+
+	return t
+}
+
+func gatewayList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("gateway list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	gateways, err := gridClient.Gateway().ListGatewayConfigs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(gateways, func() table { return gatewayTable(*gateways...) })
+}
+
+func gatewayGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("gateway get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	gateway, err := gridClient.Gateway().GetGatewayConfigById(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(gateway, func() table { return gatewayTable(*gateway) })
+}
+
+func gatewayCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("gateway create")
+	file := fs.String("f", "", "JSON or YAML file with the load balancer endpoint, - for stdin")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	gateway := &models.GatewayConfig{}
+	err := readSpec(*file, gateway)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	created, err := gridClient.Gateway().CreateGatewayConfig(ctx, gateway)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return gatewayTable(*created) })
+}
+
+func gatewayDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("gateway delete")
+	yes := fs.Bool("yes", false, "delete the load balancer endpoint without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete load balancer endpoint "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	err = gridClient.Gateway().DeleteGatewayConfig(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted load balancer endpoint %s", positional[0])
+	return nil
+}
+
+func gatewayServerConfig(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("gateway server-config")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	config, err := gridClient.Gateway().GetGatewayServerConfig(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(config, func() table {
+		restrictions := ""
+		if config.AccountRestrictions != nil {
+			restrictions = strings.Join(*config.AccountRestrictions, ",")
+		}
+		t := table{header: []string{"SERVICE TYPE", "ACCOUNT RESTRICTION", "ACCOUNTS", "CERTIFICATE"}}
+		t.add(str(config.DefaultServiceType), str(config.AccountRestrictionMode), restrictions, str(config.CertSource))
+		return t
+	})
+}
+
+func healthResource() resource {
+	return resource{
+		name:    "health",
+		summary: "health of the grid",
+		commands: []command{
+			{name: "get", summary: "show alarms, alerts and node connectivity", run: healthGet},
+		},
+	}
+}
+
+func healthGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("health get")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	gridClient, err := a.gridClient()
+	if err != nil {
+		return err
+	}
+
+	health, err := gridClient.Health().Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(health, func() table {
+		t := table{header: []string{"ALL GREEN", "CRITICAL ALERTS", "MAJOR ALERTS", "MINOR ALERTS", "NODES CONNECTED", "NODES DOWN", "NODES UNKNOWN"}}
+		alerts := &models.Alerts{}
+		if health.Alerts != nil {
+			alerts = health.Alerts
+		}
+		nodes := &models.Nodes{}
+		if health.Nodes != nil {
+			nodes = health.Nodes
+		}
+		t.add(strconv.FormatBool(health.AllGreen()), intStr(alerts.Critical), intStr(alerts.Major), intStr(alerts.Minor),
+			intStr(nodes.Connected), intStr(nodes.AdministrativelyDown), intStr(nodes.Unknown))
+		return t
+	})
+}
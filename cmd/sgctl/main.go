@@ -0,0 +1,263 @@
+// Command sgctl manages StorageGRID grids and tenant accounts from the command line.
+//
+// Usage:
+//
+//	sgctl [flags] <resource> <command> [flags] [arguments]
+//
+// Grid resources (tenant, hagroup, gateway, health) need grid administrator credentials,
+// tenant resources (bucket, user, group, key) need credentials with an account ID.
+// Connection settings are read from flags, STORAGEGRID_* environment variables and named
+// profiles in ~/.storagegrid/config.yaml, in this order.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+)
+
+// command is an action on a resource, e.g. "tenant list"
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+// resource groups the commands of a resource. The first command runs when none is given.
+type resource struct {
+	name     string
+	summary  string
+	commands []command
+}
+
+var errUsage = errors.New("invalid usage")
+
+func resources() []resource {
+	return []resource{
+		tenantResource(),
+		bucketResource(),
+		userResource(),
+		groupResource(),
+		keyResource(),
+		haGroupResource(),
+		gatewayResource(),
+		healthResource(),
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		if err != errUsage {
+			fmt.Fprintf(os.Stderr, "\nsgctl: %v\n", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "sgctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	a := &app{stdout: stdout, stderr: stderr}
+
+	fs := flag.NewFlagSet("sgctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { a.usage(fs) }
+	a.options.register(fs)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		a.usage(fs)
+		return errUsage
+	}
+
+	for _, r := range resources() {
+		if r.name != fs.Arg(0) {
+			continue
+		}
+
+		rest := fs.Args()[1:]
+		cmd := r.commands[0]
+		if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+			found := false
+			for _, c := range r.commands {
+				if c.name == rest[0] {
+					cmd, found = c, true
+					break
+				}
+			}
+			if !found {
+				a.resourceUsage(r)
+				return fmt.Errorf("%w: unknown command %q for %s", errUsage, rest[0], r.name)
+			}
+			rest = rest[1:]
+		}
+
+		err = cmd.run(ctx, a, rest)
+		if errors.Is(err, errUsage) {
+			a.resourceUsage(r)
+		}
+		return err
+	}
+
+	a.usage(fs)
+	return fmt.Errorf("%w: unknown resource %q", errUsage, fs.Arg(0))
+}
+
+func (a *app) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: sgctl [flags] <resource> <command> [flags] [arguments]")
+	fmt.Fprintln(a.stderr, "\nResources:")
+	w := tabwriter.NewWriter(a.stderr, 0, 4, 2, ' ', 0)
+	for _, r := range resources() {
+		fmt.Fprintf(w, "  %s\t%s\n", r.name, r.summary)
+	}
+	_ = w.Flush()
+	fmt.Fprintln(a.stderr, "\nFlags:")
+	fs.PrintDefaults()
+}
+
+func (a *app) resourceUsage(r resource) {
+	fmt.Fprintf(a.stderr, "Usage: sgctl %s <command> [flags] [arguments]\n\nCommands:\n", r.name)
+	w := tabwriter.NewWriter(a.stderr, 0, 4, 2, ' ', 0)
+	for _, c := range r.commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", c.name, c.args, c.summary)
+	}
+	_ = w.Flush()
+}
+
+// parse parses flags and arguments in any order and checks the number of arguments
+func (a *app) parse(fs *flag.FlagSet, args []string, wanted int) ([]string, error) {
+	fs.SetOutput(a.stderr)
+	a.options.register(fs)
+
+	positional := []string{}
+	for {
+		err := fs.Parse(args)
+		if err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != wanted {
+		return nil, fmt.Errorf("%w: %s expects %d argument(s), got %d", errUsage, fs.Name(), wanted, len(positional))
+	}
+
+	return positional, nil
+}
+
+// confirm asks the user to confirm a destructive action unless yes is set. If stdin is a pipe or a file,
+// e.g. in scripts, the action is refused without asking. Anything but "y" or "yes" aborts.
+func (a *app) confirm(yes bool, action string) error {
+	if yes {
+		return nil
+	}
+
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("refusing to %s without -yes", action)
+	}
+
+	fmt.Fprintf(a.stderr, "%s? This cannot be undone [y/N] ", action)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errors.New("aborted, pass -yes to confirm without asking")
+	}
+}
+
+// readSecret reads a password from file if given, otherwise from stdin, prompting for it without echo if stdin
+// is a terminal. Passwords are never accepted as arguments, which end up in the shell history and process listings.
+func (a *app) readSecret(file string, prompt string) (string, error) {
+	var secret string
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file: %w", err)
+		}
+		secret, _, _ = strings.Cut(string(data), "\n")
+	} else {
+		info, err := os.Stdin.Stat()
+		terminal := err == nil && info.Mode()&os.ModeCharDevice != 0
+		if terminal {
+			fmt.Fprintf(a.stderr, "%s: ", prompt)
+			err = stty("-echo")
+			if err != nil {
+				return "", fmt.Errorf("failed to disable echo, pass -password-file instead: %w", err)
+			}
+			defer func() {
+				_ = stty("echo")
+				fmt.Fprintln(a.stderr)
+			}()
+		}
+
+		secret, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+	}
+
+	secret = strings.TrimSuffix(strings.TrimSuffix(secret, "\n"), "\r")
+	if secret == "" {
+		return "", fmt.Errorf("%w: no password given on stdin or with -password-file", errUsage)
+	}
+
+	return secret, nil
+}
+
+// stty changes the settings of the terminal attached to stdin
+func stty(args ...string) error {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// newFlagSet returns the flag set of a command, which accepts the global flags as well
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("sgctl "+name, flag.ContinueOnError)
+}
+
+// splitList splits a comma separated flag value
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
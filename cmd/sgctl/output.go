@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// table is the tabular form of a result
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// print writes value in the selected output format. Tables are built lazily, as JSON and YAML do not need them.
+func (a *app) print(value interface{}, toTable func() table) error {
+	switch a.options.output {
+	case outputJSON:
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputYAML:
+		return writeYAML(a, value)
+	case outputTable:
+		t := toTable()
+		w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("%w: unknown output format %q", errUsage, a.options.output)
+	}
+}
+
+// writeYAML writes value with the field names and order of its JSON encoding, since the models only carry JSON tags
+func writeYAML(a *app, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the key order
+	node := yaml.Node{}
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(a.stdout)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&node)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// blockStyle resets the flow style of JSON collections and the quoting of plain strings
+func blockStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.DoubleQuotedStyle {
+		node.Style = 0
+	}
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// done reports a completed action without result
+func (a *app) done(format string, args ...interface{}) {
+	fmt.Fprintf(a.stdout, format+"\n", args...)
+}
+
+// readSpec decodes a JSON or YAML file into v using the JSON field names of the models, "-" reads stdin
+func readSpec(path string, v interface{}) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var decoded interface{}
+	err = yaml.Unmarshal(data, &decoded)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	data, err = json.Marshal(decoded)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func boolStr(b *bool) string {
+	if b == nil {
+		return ""
+	}
+
+	return strconv.FormatBool(*b)
+}
+
+func intStr[T int | int32 | int64](i *T) string {
+	if i == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(*i), 10)
+}
+
+func timeStr(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// bytesStr formats a byte count with binary units
+func bytesStr(b *int64) string {
+	if b == nil {
+		return ""
+	}
+
+	const unit = 1024
+	if *b < unit {
+		return fmt.Sprintf("%d B", *b)
+	}
+
+	div, exp := int64(unit), 0
+	for n := *b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(*b)/float64(div), "KMGTPE"[exp])
+}
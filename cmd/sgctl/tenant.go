@@ -0,0 +1,690 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func bucketResource() resource {
+	return resource{
+		name:    "bucket",
+		summary: "buckets of a tenant account",
+		commands: []command{
+			{name: "list", summary: "list buckets", run: bucketList},
+			{name: "get", args: "<name>", summary: "show a bucket", run: bucketGet},
+			{name: "create", args: "<name>", summary: "create a bucket", run: bucketCreate},
+			{name: "delete", args: "<name>", summary: "delete an empty bucket, asks for confirmation unless -yes", run: bucketDelete},
+			{name: "drain", args: "<name>", summary: "delete all objects of a bucket in the background, asks for confirmation unless -yes", run: bucketDrain},
+			{name: "drain-status", args: "<name>", summary: "show the progress of a drain", run: bucketDrainStatus},
+			{name: "usage", args: "<name>", summary: "show the storage usage of a bucket", run: bucketUsage},
+		},
+	}
+}
+
+func bucketTable(buckets ...models.Bucket) table {
+	t := table{header: []string{"NAME", "REGION", "CREATED", "VERSIONING", "OBJECT LOCK"}}
+	for _, bucket := range buckets {
+		objectLock := ""
+		if bucket.S3ObjectLock != nil {
+			objectLock = boolStr(bucket.S3ObjectLock.Enabled)
+		}
+		t.add(bucket.Name, bucket.Region, timeStr(&bucket.CreationTime), boolStr(bucket.EnableVersioning), objectLock)
+	}
+
+	return t
+}
+
+func drainTable(status *models.BucketDeleteObjectStatus) table {
+	t := table{header: []string{"DELETING OBJECTS", "INITIAL OBJECTS", "INITIAL DATA"}}
+	t.add(boolStr(status.IsDeletingObjects), intStr(status.InitialObjectCount), bytesStr(status.InitialObjectBytes))
+
+	return t
+}
+
+func bucketList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	buckets, err := tenantClient.Bucket().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(buckets, func() table { return bucketTable(*buckets...) })
+}
+
+func bucketGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	bucket, err := tenantClient.Bucket().GetByName(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(bucket, func() table { return bucketTable(*bucket) })
+}
+
+func bucketCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket create")
+	region := fs.String("region", models.DefaultRegion, "region of the bucket")
+	versioning := fs.Bool("versioning", false, "enable versioning")
+	objectLock := fs.Bool("object-lock", false, "enable S3 Object Lock")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	bucket := &models.Bucket{Name: positional[0], Region: *region}
+	if *versioning {
+		bucket.EnableVersioning = versioning
+	}
+	if *objectLock {
+		bucket.S3ObjectLock = &models.BucketS3ObjectLockSettings{Enabled: objectLock}
+	}
+
+	created, err := tenantClient.Bucket().Create(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return bucketTable(*created) })
+}
+
+func bucketDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket delete")
+	yes := fs.Bool("yes", false, "delete the bucket without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete bucket "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	err = tenantClient.Bucket().Delete(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted bucket %s", positional[0])
+	return nil
+}
+
+func bucketDrain(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket drain")
+	yes := fs.Bool("yes", false, "delete all objects without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete all objects of bucket "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := tenantClient.Bucket().Drain(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(status, func() table { return drainTable(status) })
+}
+
+func bucketDrainStatus(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket drain-status")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := tenantClient.Bucket().DrainStatus(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(status, func() table { return drainTable(status) })
+}
+
+func bucketUsage(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("bucket usage")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	stats, err := tenantClient.Bucket().GetUsage(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return a.print(stats, func() table {
+		t := table{header: []string{"BUCKET", "REGION", "OBJECTS", "DATA"}}
+		t.add(str(stats.Name), str(stats.Region), intStr(stats.ObjectCount), bytesStr(stats.DataBytes))
+		return t
+	})
+}
+
+func userResource() resource {
+	return resource{
+		name:    "user",
+		summary: "users of a tenant account",
+		commands: []command{
+			{name: "list", summary: "list users", run: userList},
+			{name: "get", args: "<id|user/name>", summary: "show a user", run: userGet},
+			{name: "create", args: "<user/name>", summary: "create a local user", run: userCreate},
+			{name: "delete", args: "<id|user/name>", summary: "delete a user, asks for confirmation unless -yes", run: userDelete},
+			{name: "set-password", args: "<id|user/name> [-password-file <file>]", summary: "set the password of a local user, reads it from stdin or the file", run: userSetPassword},
+		},
+	}
+}
+
+func userTable(users ...models.User) table {
+	t := table{header: []string{"ID", "UNIQUE NAME", "FULL NAME", "GROUPS", "DISABLED"}}
+	for _, user := range users {
+		t.add(str(user.Id), user.UniqueName, str(user.FullName), fmt.Sprint(len(user.MemberOf)), boolStr(user.Disable))
+	}
+
+	return t
+}
+
+// userId resolves a unique name like user/alice to the ID of the user
+func userId(ctx context.Context, tenantClient *client.TenantClient, idOrName string) (string, error) {
+	if !strings.Contains(idOrName, "/") {
+		return idOrName, nil
+	}
+
+	user, err := tenantClient.Users().GetByName(ctx, idOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return str(user.Id), nil
+}
+
+func userList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	users, err := tenantClient.Users().Iter().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(users, func() table { return userTable(*users...) })
+}
+
+func userGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := userId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	user, err := tenantClient.Users().GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.print(user, func() table { return userTable(*user) })
+}
+
+func userCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user create")
+	fullName := fs.String("full-name", "", "full name of the user")
+	groups := fs.String("groups", "", "comma separated unique names of the groups of the user")
+	disable := fs.Bool("disable", false, "prevent the user from signing in")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{UniqueName: positional[0], Disable: disable}
+	if !strings.Contains(user.UniqueName, "/") {
+		user.UniqueName = "user/" + user.UniqueName
+	}
+	if *fullName != "" {
+		user.FullName = fullName
+	}
+	if names := splitList(*groups); len(names) > 0 {
+		user.MemberOf, err = tenantClient.Groups().ResolveIds(ctx, names)
+		if err != nil {
+			return err
+		}
+	}
+
+	created, err := tenantClient.Users().Create(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return userTable(*created) })
+}
+
+func userDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user delete")
+	yes := fs.Bool("yes", false, "delete the user without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete user "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := userId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	err = tenantClient.Users().Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted user %s", positional[0])
+	return nil
+}
+
+func userSetPassword(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("user set-password")
+	passwordFile := fs.String("password-file", "", "file with the new password of the user, read from stdin if not given")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	password, err := a.readSecret(*passwordFile, "New password")
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := userId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	err = tenantClient.Users().SetPassword(ctx, id, password)
+	if err != nil {
+		return err
+	}
+
+	a.done("set password of user %s", positional[0])
+	return nil
+}
+
+func groupResource() resource {
+	return resource{
+		name:    "group",
+		summary: "groups of a tenant account",
+		commands: []command{
+			{name: "list", summary: "list groups", run: groupList},
+			{name: "get", args: "<id|group/name>", summary: "show a group", run: groupGet},
+			{name: "create", args: "<group/name>", summary: "create a local group", run: groupCreate},
+			{name: "delete", args: "<id|group/name>", summary: "delete a group, asks for confirmation unless -yes", run: groupDelete},
+			{name: "members", args: "<id|group/name>", summary: "list the members of a group", run: groupMembers},
+		},
+	}
+}
+
+func groupTable(groups ...models.TenantGroup) table {
+	t := table{header: []string{"ID", "UNIQUE NAME", "DISPLAY NAME", "READ ONLY", "FEDERATED"}}
+	for _, group := range groups {
+		t.add(str(group.Id), group.UniqueName, group.DisplayName, boolStr(group.ManagementReadOnly), boolStr(group.Federated))
+	}
+
+	return t
+}
+
+// groupId resolves a unique name like group/admins to the ID of the group
+func groupId(ctx context.Context, tenantClient *client.TenantClient, idOrName string) (string, error) {
+	if !strings.Contains(idOrName, "/") {
+		return idOrName, nil
+	}
+
+	group, err := tenantClient.Groups().GetByName(ctx, idOrName)
+	if err != nil {
+		return "", err
+	}
+
+	return str(group.Id), nil
+}
+
+func groupList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("group list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	groups, err := tenantClient.Groups().Iter().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(groups, func() table { return groupTable(*groups...) })
+}
+
+func groupGet(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("group get")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := groupId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	group, err := tenantClient.Groups().GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.print(group, func() table { return groupTable(*group) })
+}
+
+func groupCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("group create")
+	displayName := fs.String("display-name", "", "display name of the group, defaults to the name")
+	readOnly := fs.Bool("read-only", false, "members can view but not change settings")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	group := &models.TenantGroup{UniqueName: positional[0], DisplayName: *displayName, ManagementReadOnly: readOnly}
+	if !strings.Contains(group.UniqueName, "/") {
+		group.UniqueName = "group/" + group.UniqueName
+	}
+	if group.DisplayName == "" {
+		group.DisplayName = strings.SplitN(group.UniqueName, "/", 2)[1]
+	}
+
+	created, err := tenantClient.Groups().Create(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return groupTable(*created) })
+}
+
+func groupDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("group delete")
+	yes := fs.Bool("yes", false, "delete the group without asking for confirmation")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete group "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := groupId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	err = tenantClient.Groups().Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted group %s", positional[0])
+	return nil
+}
+
+func groupMembers(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("group members")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := groupId(ctx, tenantClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	users, err := tenantClient.Groups().ListMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.print(users, func() table { return userTable(*users...) })
+}
+
+func keyResource() resource {
+	return resource{
+		name:    "key",
+		summary: "S3 access keys of the signed in user or, with -user, of another user",
+		commands: []command{
+			{name: "list", summary: "list access keys", run: keyList},
+			{name: "create", summary: "create an access key, the secret is only shown once", run: keyCreate},
+			{name: "delete", args: "<id>", summary: "delete an access key, asks for confirmation unless -yes", run: keyDelete},
+		},
+	}
+}
+
+func keyTable(keys ...models.S3AccessKey) table {
+	t := table{header: []string{"ID", "ACCESS KEY", "SECRET ACCESS KEY", "EXPIRES"}}
+	for _, key := range keys {
+		accessKey := str(key.AccessKey)
+		if accessKey == "" {
+			accessKey = str(key.DisplayName)
+		}
+		t.add(str(key.Id), accessKey, str(key.SecretAccessKey), timeStr(key.Expires))
+	}
+
+	return t
+}
+
+func keyList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("key list")
+	user := fs.String("user", "", "ID or unique name of the user, defaults to the signed in user")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	var keys *[]models.S3AccessKey
+	if *user == "" {
+		keys, err = tenantClient.S3AccessKeys().ListForCurrentUser(ctx)
+	} else {
+		var id string
+		id, err = userId(ctx, tenantClient, *user)
+		if err == nil {
+			keys, err = tenantClient.S3AccessKeys().ListForUser(ctx, id)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.print(keys, func() table { return keyTable(*keys...) })
+}
+
+func keyCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("key create")
+	user := fs.String("user", "", "ID or unique name of the user, defaults to the signed in user")
+	lifetime := fs.Duration("expires-in", 0, "validity of the key, e.g. 720h, 0 never expires")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	key := &models.S3AccessKey{}
+	if *lifetime > 0 {
+		expires := time.Now().Add(*lifetime).UTC()
+		key.Expires = &expires
+	}
+
+	var created *models.S3AccessKey
+	if *user == "" {
+		created, err = tenantClient.S3AccessKeys().CreateForCurrentUser(ctx, key)
+	} else {
+		var id string
+		id, err = userId(ctx, tenantClient, *user)
+		if err == nil {
+			created, err = tenantClient.S3AccessKeys().CreateForUser(ctx, id, key)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.print(created, func() table { return keyTable(*created) })
+}
+
+func keyDelete(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("key delete")
+	yes := fs.Bool("yes", false, "delete the access key without asking for confirmation")
+	user := fs.String("user", "", "ID or unique name of the user, defaults to the signed in user")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	err = a.confirm(*yes, "delete access key "+positional[0])
+	if err != nil {
+		return err
+	}
+
+	tenantClient, err := a.tenantClient()
+	if err != nil {
+		return err
+	}
+
+	if *user == "" {
+		err = tenantClient.S3AccessKeys().DeleteForCurrentUser(ctx, positional[0])
+	} else {
+		var id string
+		id, err = userId(ctx, tenantClient, *user)
+		if err == nil {
+			err = tenantClient.S3AccessKeys().DeleteForUser(ctx, id, positional[0])
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	a.done("deleted access key %s", positional[0])
+	return nil
+}
@@ -4,40 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/yehlo/storagegrid-sdk-go/client"
 	"github.com/yehlo/storagegrid-sdk-go/models"
 )
 
 func main() {
-	// Get configuration from environment
-	endpoint := os.Getenv("STORAGEGRID_ENDPOINT")
-	username := os.Getenv("STORAGEGRID_USERNAME")
-	password := os.Getenv("STORAGEGRID_PASSWORD")
-	skipSSL := os.Getenv("STORAGEGRID_SKIP_SSL") == "true"
-
-	if endpoint == "" || username == "" || password == "" {
-		log.Fatal("Required environment variables: STORAGEGRID_ENDPOINT, STORAGEGRID_USERNAME, STORAGEGRID_PASSWORD")
-	}
-
 	ctx := context.Background()
 
-	// Configure client options
-	opts := []client.ClientOption{
-		client.WithEndpoint(endpoint),
-		client.WithCredentials(&models.Credentials{
-			Username: username,
-			Password: password,
-		}),
-	}
-
-	if skipSSL {
-		opts = append(opts, client.WithSkipSSL())
-	}
-
-	// Create grid client
-	gridClient, err := client.NewGridClient(opts...)
+	// Read the connection settings from STORAGEGRID_* variables or a profile in ~/.storagegrid/config.yaml
+	gridClient, err := client.NewGridClient(client.FromEnv(), client.FromProfile(""))
 	if err != nil {
 		log.Fatalf("Failed to create grid client: %v", err)
 	}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/reconcile"
+)
+
+func main() {
+	specPath := flag.String("f", "tenants.yaml", "spec file")
+	prune := flag.String("prune", "none", "delete resources missing from the spec: none, resources or all")
+	apply := flag.Bool("apply", false, "apply the plan instead of only printing it")
+	flag.Parse()
+
+	modes := map[string]reconcile.PruneMode{
+		"none":      reconcile.PruneNone,
+		"resources": reconcile.PruneResources,
+		"all":       reconcile.PruneAll,
+	}
+	mode, ok := modes[*prune]
+	if !ok {
+		log.Fatalf("Unknown prune mode %q", *prune)
+	}
+
+	ctx := context.Background()
+
+	spec, err := reconcile.LoadSpec(*specPath)
+	if err != nil {
+		log.Fatalf("Failed to load spec: %v", err)
+	}
+
+	// Read the connection settings from STORAGEGRID_* variables or a profile in ~/.storagegrid/config.yaml
+	gridClient, err := client.NewGridClient(client.FromEnv(), client.FromProfile(""))
+	if err != nil {
+		log.Fatalf("Failed to create grid client: %v", err)
+	}
+
+	// Tenants are managed as their root user, with the rootPassword of the spec
+	connector := reconcile.RootConnector(client.FromEnv(), client.FromProfile(""))
+	reconciler := reconcile.New(gridClient, connector, reconcile.WithPrune(mode))
+
+	plan, err := reconciler.Plan(ctx, spec)
+	if err != nil {
+		log.Fatalf("Failed to plan: %v", err)
+	}
+	fmt.Print(plan)
+
+	if !*apply || plan.Empty() {
+		return
+	}
+
+	err = reconciler.Apply(ctx, plan)
+	if err != nil {
+		log.Fatalf("Failed to apply: %v", err)
+	}
+	fmt.Println("✅ Applied")
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"github.com/yehlo/storagegrid-sdk-go/client"
@@ -12,35 +11,10 @@ import (
 )
 
 func main() {
-	// Get configuration from environment
-	endpoint := os.Getenv("STORAGEGRID_ENDPOINT")
-	accountID := os.Getenv("STORAGEGRID_ACCOUNT_ID")
-	username := os.Getenv("STORAGEGRID_TENANT_USERNAME")
-	password := os.Getenv("STORAGEGRID_TENANT_PASSWORD")
-	skipSSL := os.Getenv("STORAGEGRID_SKIP_SSL") == "true"
-
-	if endpoint == "" || accountID == "" || username == "" || password == "" {
-		log.Fatal("Required environment variables: STORAGEGRID_ENDPOINT, STORAGEGRID_ACCOUNT_ID, STORAGEGRID_TENANT_USERNAME, STORAGEGRID_TENANT_PASSWORD")
-	}
-
 	ctx := context.Background()
 
-	// Configure client options
-	opts := []client.ClientOption{
-		client.WithEndpoint(endpoint),
-		client.WithCredentials(&models.Credentials{
-			Username:  username,
-			Password:  password,
-			AccountId: &accountID,
-		}),
-	}
-
-	if skipSSL {
-		opts = append(opts, client.WithSkipSSL())
-	}
-
-	// Create tenant client
-	tenantClient, err := client.NewTenantClient(opts...)
+	// Read the connection settings from STORAGEGRID_* variables or a profile in ~/.storagegrid/config.yaml
+	tenantClient, err := client.NewTenantClient(client.FromEnv(), client.FromProfile(""))
 	if err != nil {
 		log.Fatalf("Failed to create tenant client: %v", err)
 	}
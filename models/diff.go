@@ -0,0 +1,233 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldChange is a field whose desired value differs from its actual value
+type FieldChange struct {
+	// Path is the JSON path of the field, e.g. "policy.quotaObjectBytes" or "interfaces[0].nodeId"
+	Path string `json:"path"`
+	// Desired is the specified value, nil for map entries that are to be removed
+	Desired interface{} `json:"desired"`
+	// Actual is the current value, nil if it is not set
+	Actual interface{} `json:"actual"`
+}
+
+// serverFields are generated by the grid and never part of a desired state
+var serverFields = map[string]bool{
+	"Id":           true,
+	"GroupURN":     true,
+	"UserURN":      true,
+	"CreationTime": true,
+	"ResponseTime": true,
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	identifierPath = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// Diff compares two values of the same model type and returns the fields where desired differs from actual.
+//
+// Nil pointers, slices and maps, and zero values of fields tagged omitempty, are unspecified in desired and
+// not compared. Other fields, e.g. booleans without omitempty, are always compared. Structs are compared field by field, slices of equal length element by element, and maps
+// entry by entry. Server-generated fields (Id, GroupURN, UserURN, CreationTime, ResponseTime) are ignored.
+func Diff(desired interface{}, actual interface{}) []FieldChange {
+	changes := []FieldChange{}
+
+	d, a := reflect.ValueOf(desired), reflect.ValueOf(actual)
+	if d.IsValid() && a.IsValid() && d.Type() != a.Type() {
+		return append(changes, FieldChange{Desired: desired, Actual: actual})
+	}
+	diffValue(&changes, "", d, a)
+
+	return changes
+}
+
+// DiffTenant compares tenants. Capabilities are compared regardless of their order and the write-only password is ignored.
+//
+// A desired Policy is compared completely, since UseAccountIdentitySource and AllowPlatformServices are plain booleans
+// that cannot be left unspecified. Start from a copy of the actual policy to manage only some of its fields.
+func DiffTenant(desired *Tenant, actual *Tenant) []FieldChange {
+	if desired == nil {
+		return []FieldChange{}
+	}
+
+	d := *desired
+	d.Password = nil
+	d.Capabilities = SortedCopy(d.Capabilities)
+	if actual != nil {
+		a := *actual
+		a.Password = nil
+		a.Capabilities = SortedCopy(a.Capabilities)
+		actual = &a
+	}
+
+	return Diff(&d, actual)
+}
+
+// DiffTenantGroup compares tenant groups
+func DiffTenantGroup(desired *TenantGroup, actual *TenantGroup) []FieldChange {
+	return Diff(desired, actual)
+}
+
+// DiffGatewayConfig compares load balancer endpoints
+func DiffGatewayConfig(desired *GatewayConfig, actual *GatewayConfig) []FieldChange {
+	return Diff(desired, actual)
+}
+
+// DiffHAGroup compares HA groups. Interfaces keep their order, as the first one is the preferred primary.
+func DiffHAGroup(desired *HAGroup, actual *HAGroup) []FieldChange {
+	return Diff(desired, actual)
+}
+
+// diffValue records the differences of a specified desired value. An invalid actual value is not set.
+func diffValue(changes *[]FieldChange, path string, desired reflect.Value, actual reflect.Value) {
+	if !specified(desired) {
+		return
+	}
+	desired, actual = indirect(desired), indirect(actual)
+
+	switch {
+	case desired.Type() == timeType:
+		if actual.IsValid() && desired.Interface().(time.Time).Equal(actual.Interface().(time.Time)) {
+			return
+		}
+	case desired.Kind() == reflect.Struct:
+		diffStruct(changes, path, desired, actual)
+		return
+	case desired.Kind() == reflect.Slice || desired.Kind() == reflect.Array:
+		if specified(actual) && actual.Len() == desired.Len() {
+			for i := 0; i < desired.Len(); i++ {
+				diffValue(changes, fmt.Sprintf("%s[%d]", path, i), desired.Index(i), actual.Index(i))
+			}
+			return
+		}
+	case desired.Kind() == reflect.Map:
+		if specified(actual) {
+			diffMap(changes, path, desired, actual)
+			return
+		}
+	default:
+		if actual.IsValid() && reflect.DeepEqual(desired.Interface(), actual.Interface()) {
+			return
+		}
+	}
+
+	*changes = append(*changes, FieldChange{Path: path, Desired: desired.Interface(), Actual: valueOf(actual)})
+}
+
+func diffStruct(changes *[]FieldChange, path string, desired reflect.Value, actual reflect.Value) {
+	t := desired.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || serverFields[field.Name] {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := desired.Field(i)
+		if slices.Contains(strings.Split(options, ","), "omitempty") && value.IsZero() {
+			continue
+		}
+
+		var actualValue reflect.Value
+		if actual.IsValid() {
+			actualValue = actual.Field(i)
+		}
+
+		fieldPath := joinPath(path, name)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			fieldPath = path
+		}
+		diffValue(changes, fieldPath, value, actualValue)
+	}
+}
+
+// diffMap compares the entries of both maps, entries missing from desired are reported with a nil desired value
+func diffMap(changes *[]FieldChange, path string, desired reflect.Value, actual reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, key := range desired.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = key
+	}
+	for _, key := range actual.MapKeys() {
+		keys[fmt.Sprint(key.Interface())] = key
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		desiredValue, actualValue := desired.MapIndex(key), actual.MapIndex(key)
+		if !desiredValue.IsValid() {
+			*changes = append(*changes, FieldChange{Path: joinPath(path, name), Actual: valueOf(indirect(actualValue))})
+			continue
+		}
+		diffValue(changes, joinPath(path, name), desiredValue, actualValue)
+	}
+}
+
+// specified reports whether a value is set, i.e. valid and not a nil pointer, interface, slice or map
+func specified(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return !v.IsNil()
+	default:
+		return true
+	}
+}
+
+// indirect dereferences pointers and interfaces and returns an invalid value for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !specified(v) {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// joinPath appends a JSON field name, using bracket notation for names that are not identifiers
+func joinPath(path string, name string) string {
+	if !identifierPath.MatchString(name) {
+		return path + "[" + strconv.Quote(name) + "]"
+	}
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// DiffBase is embedded without a JSON name, so its fields appear at the level of the embedding struct
+type DiffBase struct {
+	Label string `json:"label,omitempty"`
+}
+
+type diffItem struct {
+	Name  string `json:"name"`
+	Count *int   `json:"count,omitempty"`
+}
+
+type diffModel struct {
+	DiffBase
+	Id           *string           `json:"id,omitempty"`
+	CreationTime *time.Time        `json:"creationTime,omitempty"`
+	Enabled      bool              `json:"enabled"`
+	Size         *int64            `json:"size,omitempty"`
+	Modified     *time.Time        `json:"modified,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Items        []diffItem        `json:"items,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+func TestDiff(t *testing.T) {
+	id1, id2 := "1", "2"
+	size1, size2 := int64(1), int64(2)
+	count1, count2 := 1, 2
+	now := time.Now()
+	later := now.Add(time.Hour)
+	sameInstant := now.In(time.FixedZone("UTC+2", 2*60*60))
+
+	tests := []struct {
+		name     string
+		desired  diffModel
+		actual   diffModel
+		expected []FieldChange
+	}{
+		{
+			name:     "unspecified fields are not compared",
+			desired:  diffModel{},
+			actual:   diffModel{Size: &size1, Tags: []string{"a"}, Labels: map[string]string{"a": "1"}, DiffBase: DiffBase{Label: "a"}},
+			expected: []FieldChange{},
+		},
+		{
+			name:     "booleans are always compared",
+			desired:  diffModel{},
+			actual:   diffModel{Enabled: true},
+			expected: []FieldChange{{Path: "enabled", Desired: false, Actual: true}},
+		},
+		{
+			name:     "pointer values are compared",
+			desired:  diffModel{Size: &size1},
+			actual:   diffModel{Size: &size2},
+			expected: []FieldChange{{Path: "size", Desired: size1, Actual: size2}},
+		},
+		{
+			name:     "unset actual pointer",
+			desired:  diffModel{Size: &size1},
+			actual:   diffModel{},
+			expected: []FieldChange{{Path: "size", Desired: size1, Actual: nil}},
+		},
+		{
+			name:     "times are compared as instants",
+			desired:  diffModel{Modified: &now},
+			actual:   diffModel{Modified: &sameInstant},
+			expected: []FieldChange{},
+		},
+		{
+			name:     "different times",
+			desired:  diffModel{Modified: &later},
+			actual:   diffModel{Modified: &now},
+			expected: []FieldChange{{Path: "modified", Desired: later, Actual: now}},
+		},
+		{
+			name:     "slices of equal length are compared by element",
+			desired:  diffModel{Tags: []string{"a", "b"}},
+			actual:   diffModel{Tags: []string{"a", "c"}},
+			expected: []FieldChange{{Path: "tags[1]", Desired: "b", Actual: "c"}},
+		},
+		{
+			name:     "slices of different length are replaced",
+			desired:  diffModel{Tags: []string{"a"}},
+			actual:   diffModel{Tags: []string{"a", "b"}},
+			expected: []FieldChange{{Path: "tags", Desired: []string{"a"}, Actual: []string{"a", "b"}}},
+		},
+		{
+			name:     "structs in slices are compared by field",
+			desired:  diffModel{Items: []diffItem{{Name: "a", Count: &count1}}},
+			actual:   diffModel{Items: []diffItem{{Name: "a", Count: &count2}}},
+			expected: []FieldChange{{Path: "items[0].count", Desired: count1, Actual: count2}},
+		},
+		{
+			name:    "maps are compared by entry",
+			desired: diffModel{Labels: map[string]string{"a": "1", "x.y": "1"}},
+			actual:  diffModel{Labels: map[string]string{"a": "2", "b": "3", "x.y": "1"}},
+			expected: []FieldChange{
+				{Path: "labels.a", Desired: "1", Actual: "2"},
+				{Path: "labels.b", Desired: nil, Actual: "3"},
+			},
+		},
+		{
+			name:     "map keys that are not identifiers",
+			desired:  diffModel{Labels: map[string]string{"x.y": "1"}},
+			actual:   diffModel{Labels: map[string]string{"x.y": "2"}},
+			expected: []FieldChange{{Path: `labels["x.y"]`, Desired: "1", Actual: "2"}},
+		},
+		{
+			name:     "embedded structs are flattened",
+			desired:  diffModel{DiffBase: DiffBase{Label: "a"}},
+			actual:   diffModel{DiffBase: DiffBase{Label: "b"}},
+			expected: []FieldChange{{Path: "label", Desired: "a", Actual: "b"}},
+		},
+		{
+			name:     "server fields are ignored",
+			desired:  diffModel{Id: &id1, CreationTime: &now},
+			actual:   diffModel{Id: &id2, CreationTime: &later},
+			expected: []FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(&tt.desired, &tt.actual)
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("Diff() = %#v, want %#v", changes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDiff_DifferentTypes(t *testing.T) {
+	changes := Diff(&diffModel{}, &diffItem{})
+	if len(changes) != 1 || changes[0].Path != "" {
+		t.Errorf("Diff() = %#v, want a single change of the whole value", changes)
+	}
+}
+
+func TestDiffTenant(t *testing.T) {
+	name := "acme"
+	password1, password2 := "secret-1", "secret-2"
+	quota1, quota2 := int64(1), int64(2)
+
+	tests := []struct {
+		name     string
+		desired  *Tenant
+		actual   *Tenant
+		expected []FieldChange
+	}{
+		{
+			name:     "capabilities in any order",
+			desired:  &Tenant{Name: &name, Capabilities: []string{"s3", "management"}},
+			actual:   &Tenant{Name: &name, Capabilities: []string{"management", "s3"}},
+			expected: []FieldChange{},
+		},
+		{
+			name:     "password is ignored",
+			desired:  &Tenant{Name: &name, Password: &password1},
+			actual:   &Tenant{Name: &name, Password: &password2},
+			expected: []FieldChange{},
+		},
+		{
+			name:     "policy is compared completely",
+			desired:  &Tenant{Policy: &TenantPolicy{QuotaObjectBytes: &quota2}},
+			actual:   &Tenant{Policy: &TenantPolicy{AllowPlatformServices: true, QuotaObjectBytes: &quota1}},
+			expected: []FieldChange{{Path: "policy.allowPlatformServices", Desired: false, Actual: true}, {Path: "policy.quotaObjectBytes", Desired: quota2, Actual: quota1}},
+		},
+		{
+			name:     "no desired tenant",
+			desired:  nil,
+			actual:   &Tenant{Name: &name},
+			expected: []FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := DiffTenant(tt.desired, tt.actual)
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("DiffTenant() = %#v, want %#v", changes, tt.expected)
+			}
+		})
+	}
+}
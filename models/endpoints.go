@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Endpoint is a platform services endpoint of a tenant account, the destination of CloudMirror replication,
+// event notifications and search integration.
+type Endpoint struct {
+	// the unique identifier of the endpoint
+	Id string `json:"id,omitempty"`
+	// the display name of the endpoint
+	DisplayName string `json:"displayName"`
+	// the URI of the external service, e.g. "https://s3.example.com"
+	EndpointURI string `json:"endpointURI"`
+	// the URN of the destination, e.g. "arn:aws:s3:::bucket" or "arn:aws:sns:us-east-1:123456789012:topic". It is unique within the tenant account.
+	EndpointURN string `json:"endpointURN"`
+	// how StorageGRID authenticates to the external service: "anonymous", "basic", "s3" or "cap"
+	AuthType *string `json:"authType,omitempty"`
+	// the credentials of the external service. Secrets are write-only and never returned by the grid.
+	Credentials *EndpointCredentials `json:"credentials,omitempty"`
+	// the PEM encoded CA certificate used to verify the external service, the system roots if empty
+	CaCert *string `json:"caCert,omitempty"`
+	// if true, the certificate of the external service is not verified
+	InsecureTLS *bool `json:"insecureTLS,omitempty"`
+	// the last error of the endpoint, if any
+	Error *EndpointError `json:"error,omitempty"`
+}
+
+// EndpointCredentials authenticate StorageGRID to the external service of an endpoint
+type EndpointCredentials struct {
+	// the access key ID for authType "s3"
+	AccessKeyId *string `json:"accessKeyId,omitempty"`
+	// the secret access key for authType "s3"
+	SecretAccessKey *string `json:"secretAccessKey,omitempty"`
+	// the username for authType "basic"
+	Username *string `json:"username,omitempty"`
+	// the password for authType "basic"
+	Password *string `json:"password,omitempty"`
+}
+
+// EndpointError is the last error StorageGRID encountered sending data to an endpoint
+type EndpointError struct {
+	Text *string    `json:"text,omitempty"`
+	Time *time.Time `json:"time,omitempty"`
+}
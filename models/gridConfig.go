@@ -0,0 +1,9 @@
+package models
+
+// GridNetworksUpdate is the request body for replacing the Grid Network subnet list.
+type GridNetworksUpdate struct {
+	// Passphrase is the provisioning passphrase of the grid.
+	Passphrase string `json:"passphrase"`
+	// Subnets contains the Grid Network subnets in CIDR notation.
+	Subnets []string `json:"subnets"`
+}
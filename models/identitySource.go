@@ -0,0 +1,26 @@
+package models
+
+// IdentitySource represents the identity federation configuration of a tenant account.
+type IdentitySource struct {
+	// if true, identity federation is disabled and only local users and groups are available
+	Disable *bool `json:"disable,omitempty"`
+	// the type of the identity source (e.g., "ad", "openldap", "other")
+	Type *string `json:"type,omitempty"`
+	// the hostname or IP address of the LDAP server
+	Hostname *string `json:"hostname,omitempty"`
+	// the port of the LDAP server
+	Port *int `json:"port,omitempty"`
+	// the distinguished name used to bind to the LDAP server
+	Username *string `json:"username,omitempty"`
+	// the fully qualified distinguished name of the LDAP subtree to search for groups
+	BaseGroupDn *string `json:"baseGroupDn,omitempty"`
+	// the fully qualified distinguished name of the LDAP subtree to search for users
+	BaseUserDn *string `json:"baseUserDn,omitempty"`
+	// the TLS mode used to connect to the LDAP server (e.g., "starttls", "ldaps", "none")
+	TlsMode *string `json:"tlsMode,omitempty"`
+}
+
+// Enabled returns true if an identity source is configured and not disabled.
+func (i *IdentitySource) Enabled() bool {
+	return i != nil && i.Type != nil && *i.Type != "" && (i.Disable == nil || !*i.Disable)
+}
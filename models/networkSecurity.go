@@ -0,0 +1,70 @@
+package models
+
+// Values for UntrustedClientNetwork.NewNodeDefault.
+const (
+	ClientNetworkTrusted   = "trusted"
+	ClientNetworkUntrusted = "untrusted"
+)
+
+// Node represents a grid node as reported by the grid topology.
+type Node struct {
+	// ID is the unique identifier of the node.
+	Id string `json:"id"`
+	// Name is the hostname of the node.
+	Name *string `json:"name,omitempty"`
+	// Type is the node type (e.g., "primaryAdmin", "storage", "gateway").
+	Type *string `json:"type,omitempty"`
+	// IsPrimaryAdmin is true if the node is the primary Admin Node.
+	IsPrimaryAdmin *bool `json:"isPrimaryAdmin,omitempty"`
+	// SiteId is the unique identifier of the site the node belongs to.
+	SiteId *string `json:"siteId,omitempty"`
+	// SiteName is the name of the site the node belongs to.
+	SiteName *string `json:"siteName,omitempty"`
+	// State is the connection state of the node (e.g., "connected").
+	State *string `json:"state,omitempty"`
+	// Severity is the most severe active alert of the node (e.g., "normal", "major").
+	Severity *string `json:"severity,omitempty"`
+}
+
+// UntrustedClientNetwork describes which node Client Network interfaces are untrusted.
+// Untrusted Client Networks only accept inbound connections on load balancer endpoints.
+type UntrustedClientNetwork struct {
+	// NewNodeDefault is the setting applied to nodes added to the grid later ("trusted" or "untrusted").
+	NewNodeDefault *string `json:"newNodeDefault,omitempty"`
+	// Nodes contains the Client Network trust setting per node.
+	Nodes []UntrustedClientNetworkNode `json:"nodes"`
+}
+
+// UntrustedClientNetworkNode is the Client Network trust setting of a single node.
+type UntrustedClientNetworkNode struct {
+	// NodeId is the unique identifier of the node.
+	NodeId string `json:"nodeId"`
+	// Untrusted is true if the Client Network of the node is untrusted.
+	Untrusted bool `json:"untrusted"`
+}
+
+// FirewallPrivilegedAddresses is the list of addresses allowed to reach all grid ports.
+type FirewallPrivilegedAddresses struct {
+	// PrivilegedIps contains IP addresses or CIDR subnets that may access closed external ports.
+	PrivilegedIps []string `json:"privilegedIps"`
+	// AllowStorageNodeAccess allows privileged addresses to access Storage Node ports directly.
+	AllowStorageNodeAccess *bool `json:"allowStorageNodeAccess,omitempty"`
+}
+
+// FirewallExternalPorts is the access control list for external ports of the grid.
+type FirewallExternalPorts struct {
+	// Ports contains the external port settings.
+	Ports []FirewallPort `json:"ports"`
+}
+
+// FirewallPort is the access setting of a single external port.
+type FirewallPort struct {
+	// Port is the port number.
+	Port int `json:"port"`
+	// Protocol is the transport protocol ("tcp" or "udp").
+	Protocol string `json:"protocol"`
+	// Open is true if the port is reachable from any address, false if only privileged addresses may access it.
+	Open bool `json:"open"`
+	// Service is the name of the service using the port (read-only).
+	Service *string `json:"service,omitempty"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Permissions represents the effective permissions of the signed-in user.
+// Tenant users carry the tenant permissions, grid administrators carry the grid permissions.
+type Permissions struct {
+	// Root-level access permission. Implies all other permissions.
+	RootAccess *bool `json:"rootAccess,omitempty"`
+
+	// Permission to manage all containers (tenant).
+	ManageAllContainers *bool `json:"manageAllContainers,omitempty"`
+	// Permission to manage endpoints (tenant).
+	ManageEndpoints *bool `json:"manageEndpoints,omitempty"`
+	// Permission to manage their own S3 credentials (tenant).
+	ManageOwnS3Credentials *bool `json:"manageOwnS3Credentials,omitempty"`
+	// Permission to manage their own container objects (tenant).
+	ManageOwnContainerObjects *bool `json:"manageOwnContainerObjects,omitempty"`
+	// Permission to view all containers (tenant).
+	ViewAllContainers *bool `json:"viewAllContainers,omitempty"`
+
+	// Permission to acknowledge alarms (grid).
+	AlarmAcknowledgment *bool `json:"alarmAcknowledgment,omitempty"`
+	// Permission to change grid configuration not covered by other permissions (grid).
+	OtherGridConfiguration *bool `json:"otherGridConfiguration,omitempty"`
+	// Permission to configure the grid topology page (grid).
+	GridTopologyPageConfiguration *bool `json:"gridTopologyPageConfiguration,omitempty"`
+	// Permission to manage tenant accounts (grid).
+	TenantAccounts *bool `json:"tenantAccounts,omitempty"`
+	// Permission to change tenant root passwords (grid).
+	ChangeTenantRootPassword *bool `json:"changeTenantRootPassword,omitempty"`
+	// Permission to perform maintenance procedures (grid).
+	Maintenance *bool `json:"maintenance,omitempty"`
+	// Permission to run metrics queries (grid).
+	MetricsQuery *bool `json:"metricsQuery,omitempty"`
+	// Permission to manage ILM (grid).
+	Ilm *bool `json:"ilm,omitempty"`
+	// Permission to look up object metadata (grid).
+	ObjectMetadata *bool `json:"objectMetadata,omitempty"`
+	// Permission to manage storage settings (grid).
+	StorageAdmin *bool `json:"storageAdmin,omitempty"`
+}
+
+// Has reports whether the permission with the given JSON name (e.g., "manageAllContainers") is granted.
+// Root access grants every permission.
+func (p *Permissions) Has(name string) bool {
+	if p == nil {
+		return false
+	}
+	if p.RootAccess != nil && *p.RootAccess {
+		return true
+	}
+
+	v := reflect.ValueOf(p).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+
+		granted := v.Field(i).Interface().(*bool)
+		return granted != nil && *granted
+	}
+
+	return false
+}
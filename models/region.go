@@ -0,0 +1,25 @@
+package models
+
+// DefaultRegion is the region used for buckets created without an explicit region. It cannot be removed.
+const DefaultRegion = "us-east-1"
+
+// Region represents a region together with the buckets that reference it.
+type Region struct {
+	// Name is the name of the region.
+	Name string `json:"name"`
+	// Buckets lists every bucket that is located in the region.
+	Buckets []RegionBucket `json:"buckets,omitempty"`
+}
+
+// RegionBucket identifies a bucket of a tenant account.
+type RegionBucket struct {
+	// AccountId is the ID of the tenant account owning the bucket.
+	AccountId string `json:"accountId"`
+	// Name is the name of the bucket.
+	Name string `json:"name"`
+}
+
+// InUse returns true if at least one bucket references the region.
+func (r *Region) InUse() bool {
+	return r != nil && len(r.Buckets) > 0
+}
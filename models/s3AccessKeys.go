@@ -16,8 +16,13 @@ type S3AccessKey struct {
 	UserUUID *string `json:"userUUID,omitempty"`
 	// The time after which the key pair will no longer be valid. Null means the key pair never expires.
 	Expires *time.Time `json:"expires,omitempty"`
-	// generated automatically (returned only when generated and otherwise omitted)
+	// generated automatically (returned only when generated and otherwise omitted). Set together with SecretAccessKey to import an existing key pair.
 	AccessKey *string `json:"accessKey,omitempty"`
-	// generated automatically (returned only when generated and otherwise omitted)
+	// generated automatically (returned only when generated and otherwise omitted). Set together with AccessKey to import an existing key pair.
 	SecretAccessKey *string `json:"secretAccessKey,omitempty"`
 }
+
+// IsImport returns true if the key pair is specified by the caller instead of being generated by StorageGRID.
+func (k *S3AccessKey) IsImport() bool {
+	return k != nil && (k.AccessKey != nil || k.SecretAccessKey != nil)
+}
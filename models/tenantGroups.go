@@ -1,5 +1,11 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 type TenantGroup struct {
 	// the machine-readable name for the Group (unique within an Account; must begin with group/ or federated-group/)
 	UniqueName string `json:"uniqueName,omitempty"`
@@ -66,8 +72,69 @@ type S3Statement struct {
 	Resource []string `json:"Resource,omitempty"`
 	// Resources explicitly excluded.
 	NotResource []string `json:"NotResource,omitempty"`
-	// Conditions under which the statement applies.
-	Condition *map[string]map[string]string `json:"Condition,omitempty"`
+	// Conditions under which the statement applies, keyed by operator and condition key.
+	Condition *map[string]map[string]ConditionValues `json:"Condition,omitempty"`
+}
+
+// ConditionValues holds the values of a policy condition. In JSON a single value is written as a plain string
+// and multiple values as an array. Numbers and booleans are accepted and kept in their string form.
+type ConditionValues []string
+
+func (c ConditionValues) MarshalJSON() ([]byte, error) {
+	if len(c) == 1 {
+		return json.Marshal(c[0])
+	}
+
+	return json.Marshal([]string(c))
+}
+
+func (c *ConditionValues) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		raw := []json.RawMessage{}
+		err := json.Unmarshal(data, &raw)
+		if err != nil {
+			return err
+		}
+
+		values := make(ConditionValues, 0, len(raw))
+		for _, r := range raw {
+			value, err := conditionScalar(r)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+
+		*c = values
+		return nil
+	}
+
+	value, err := conditionScalar(data)
+	if err != nil {
+		return err
+	}
+
+	*c = ConditionValues{value}
+	return nil
+}
+
+// conditionScalar converts a JSON string, number or boolean into its string form
+func conditionScalar(data []byte) (string, error) {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		return string(bytes.TrimSpace(data)), nil
+	default:
+		return "", fmt.Errorf("unsupported condition value %s", string(data))
+	}
 }
 
 // SwiftPolicy represents the roles assigned to the group for Swift operations.
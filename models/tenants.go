@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Tenant struct {
 	// the descriptive name specified for the account (This name is for display only and might not be unique.)
 	Name *string `json:"name,omitempty"`
@@ -13,10 +15,26 @@ type Tenant struct {
 	Id string `json:"id"`
 	// Automatically assigned when generating the response. Ignored in the PUT body. Present only if this tenant account has permission to use a grid federation connection. If true, this account on the local grid is a replica of an account created on another grid. If false, this account was created on the local grid and is not a copy.
 	AccountReplica *bool `json:"accountReplica,omitempty"`
-	// the root password for the account. This field is not returned in the response.
+	// the root password for the account. This field is not returned in the response and only honored on creation; use TenantService.ResetRootPassword to change it later.
 	Password *string `json:"password,omitempty"`
 }
 
+// DeletedTenant represents a deleted tenant account whose data is still being removed from the grid.
+type DeletedTenant struct {
+	// the unique identifier of the deleted account
+	Id string `json:"id"`
+	// the descriptive name of the deleted account
+	Name *string `json:"name,omitempty"`
+	// the date and time when the account was deleted
+	DeletionTime *time.Time `json:"deletionTime,omitempty"`
+	// the state of the cleanup (e.g., "pending", "in-progress", "complete")
+	CleanupState *string `json:"cleanupState,omitempty"`
+	// the number of objects that still have to be removed
+	RemainingObjects *int64 `json:"remainingObjects,omitempty"`
+	// the number of bytes that still have to be removed
+	RemainingBytes *int64 `json:"remainingBytes,omitempty"`
+}
+
 // AccountNoIdSynchronizeRules Rules that specify which tenant data and operations will be cloned to the other grids in a grid federation connection.
 type AccountNoIdSynchronizeRules struct {
 	// If true, also create user on the other grid using the grid federation connection.
@@ -0,0 +1,77 @@
+package models
+
+// Matcher types supported by traffic classification policies.
+const (
+	TrafficMatcherBucket      = "bucket"
+	TrafficMatcherBucketRegex = "bucket-regex"
+	TrafficMatcherCidr        = "cidr"
+	TrafficMatcherEndpoint    = "endpoint"
+	TrafficMatcherTenant      = "tenant"
+	TrafficMatcherHAGroup     = "ha-group"
+)
+
+// Limit types supported by traffic classification policies.
+const (
+	TrafficLimitAggregateBandwidthIn    = "aggregateBandwidthIn"
+	TrafficLimitAggregateBandwidthOut   = "aggregateBandwidthOut"
+	TrafficLimitConcurrentReadRequests  = "concurrentReadRequests"
+	TrafficLimitConcurrentWriteRequests = "concurrentWriteRequests"
+	TrafficLimitPerRequestBandwidthIn   = "perRequestBandwidthIn"
+	TrafficLimitPerRequestBandwidthOut  = "perRequestBandwidthOut"
+	TrafficLimitReadRequestRate         = "readRequestRate"
+	TrafficLimitWriteRequestRate        = "writeRequestRate"
+)
+
+// TrafficClassificationPolicy represents a load balancer traffic classification policy.
+type TrafficClassificationPolicy struct {
+	// ID is the unique identifier of the policy (automatically assigned when a policy is created)
+	Id string `json:"id,omitempty"`
+	// Name is the human-readable name of the policy.
+	Name *string `json:"name,omitempty"`
+	// Description is the description of the policy.
+	Description *string `json:"description,omitempty"`
+	// Matchers select the traffic the policy applies to. Traffic matching any matcher is classified.
+	Matchers *[]TrafficMatcher `json:"matchers,omitempty"`
+	// Limits restrict the traffic matched by the policy.
+	Limits *[]TrafficLimit `json:"limits,omitempty"`
+}
+
+// TrafficMatcher selects traffic by bucket, tenant, subnet, load balancer endpoint or HA group.
+type TrafficMatcher struct {
+	// Type is the kind of matcher (e.g., "bucket", "tenant", "cidr", "endpoint").
+	Type string `json:"type"`
+	// Inverse matches all traffic except the traffic selected by Members.
+	Inverse *bool `json:"inverse,omitempty"`
+	// Members are the values to match, such as bucket names, tenant account IDs, CIDRs or endpoint IDs.
+	Members []string `json:"members"`
+}
+
+// TrafficLimit restricts bandwidth, request rate or concurrency of classified traffic.
+type TrafficLimit struct {
+	// Type is the kind of limit (e.g., "aggregateBandwidthIn", "readRequestRate").
+	Type string `json:"type"`
+	// Value is the limit in bytes per second, requests per second or concurrent requests, depending on Type.
+	Value int64 `json:"value"`
+}
+
+// TrafficClassificationMetrics represents the current metrics of a traffic classification policy.
+type TrafficClassificationMetrics struct {
+	// Number of requests per second matching the policy.
+	RequestRate *float64 `json:"requestRate,omitempty"`
+	// Number of read requests per second matching the policy.
+	ReadRequestRate *float64 `json:"readRequestRate,omitempty"`
+	// Number of write requests per second matching the policy.
+	WriteRequestRate *float64 `json:"writeRequestRate,omitempty"`
+	// Incoming bytes per second matching the policy.
+	BandwidthIn *float64 `json:"bandwidthIn,omitempty"`
+	// Outgoing bytes per second matching the policy.
+	BandwidthOut *float64 `json:"bandwidthOut,omitempty"`
+	// Number of concurrent read requests matching the policy.
+	ConcurrentReadRequests *int64 `json:"concurrentReadRequests,omitempty"`
+	// Number of concurrent write requests matching the policy.
+	ConcurrentWriteRequests *int64 `json:"concurrentWriteRequests,omitempty"`
+	// Average request duration in milliseconds.
+	AverageRequestDuration *float64 `json:"averageRequestDuration,omitempty"`
+	// Number of requests that were delayed or rejected because of a limit.
+	LimitedRequests *int64 `json:"limitedRequests,omitempty"`
+}
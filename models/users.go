@@ -2,6 +2,9 @@ package models
 
 import "strings"
 
+// TenantRootUsername is the unique name of the root user, which exists in every tenant account and signs in as "root"
+const TenantRootUsername = "root"
+
 type User struct {
 	// the machine-readable name for the User (unique within an Account; must begin with user/ or federated-user/). The portion after the slash is the \"username\" that is used to sign in
 	UniqueName string `json:"uniqueName"`
@@ -0,0 +1,22 @@
+package models
+
+import "slices"
+
+// Deref returns the value p points to, or the zero value of T if p is nil
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+
+	return *p
+}
+
+// SortedCopy returns a sorted copy of values, or nil if values is nil
+func SortedCopy(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return slices.Sorted(slices.Values(values))
+}
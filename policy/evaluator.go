@@ -0,0 +1,192 @@
+// Package policy evaluates and builds StorageGRID tenant group policies without contacting the grid.
+package policy
+
+import (
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// Effect is the outcome of evaluating a request against a set of policies.
+type Effect string
+
+const (
+	// Allow means at least one statement allows the request and no statement denies it.
+	Allow Effect = "Allow"
+	// Deny means a statement explicitly denies the request.
+	Deny Effect = "Deny"
+	// ImplicitDeny means no statement allows the request.
+	ImplicitDeny Effect = "ImplicitDeny"
+)
+
+// Decision describes how a request was evaluated.
+type Decision struct {
+	Effect Effect
+	// Conditional is true if the decision depends on statements with conditions. Conditions cannot be evaluated
+	// offline, so conditional Allow statements are assumed to apply and conditional Deny statements are assumed not to.
+	Conditional bool
+	// Group is the unique name of the group whose statement decided the request.
+	Group string
+	// Statement is the index of the deciding statement in the group's S3 policy, or -1 for ImplicitDeny.
+	Statement int
+}
+
+// Evaluator computes effective permissions of tenant users from their group memberships.
+type Evaluator struct {
+	groups map[string]models.TenantGroup
+}
+
+// NewEvaluator returns an Evaluator for the given groups. Groups are matched against User.MemberOf by ID.
+func NewEvaluator(groups []models.TenantGroup) *Evaluator {
+	e := &Evaluator{groups: make(map[string]models.TenantGroup, len(groups))}
+	for _, group := range groups {
+		if group.Id != nil {
+			e.groups[*group.Id] = group
+		}
+	}
+
+	return e
+}
+
+// Allowed is a shorthand for NewEvaluator(groups).Allowed(user, action, resource).
+func Allowed(user *models.User, groups []models.TenantGroup, action string, resource string) bool {
+	return NewEvaluator(groups).Allowed(user, action, resource)
+}
+
+// GroupsOf returns the known groups the user is a member of, in the order of User.MemberOf.
+func (e *Evaluator) GroupsOf(user *models.User) []models.TenantGroup {
+	groups := []models.TenantGroup{}
+	if user == nil {
+		return groups
+	}
+
+	for _, id := range user.MemberOf {
+		if group, ok := e.groups[id]; ok {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// Management returns the merged management permissions of the user. A permission is granted if any group grants it.
+// Root access implies every other management permission, as it does in the tenant management API.
+func (e *Evaluator) Management(user *models.User) models.TenantGroupManagementPolicy {
+	merged := models.TenantGroupManagementPolicy{
+		ManageAllContainers:       new(bool),
+		ManageEndpoints:           new(bool),
+		ManageOwnS3Credentials:    new(bool),
+		ManageOwnContainerObjects: new(bool),
+		ViewAllContainers:         new(bool),
+		RootAccess:                new(bool),
+	}
+
+	for _, group := range e.GroupsOf(user) {
+		if group.Policies == nil || group.Policies.Management == nil {
+			continue
+		}
+
+		m := group.Policies.Management
+		mergeBool(merged.ManageAllContainers, m.ManageAllContainers)
+		mergeBool(merged.ManageEndpoints, m.ManageEndpoints)
+		mergeBool(merged.ManageOwnS3Credentials, m.ManageOwnS3Credentials)
+		mergeBool(merged.ManageOwnContainerObjects, m.ManageOwnContainerObjects)
+		mergeBool(merged.ViewAllContainers, m.ViewAllContainers)
+		mergeBool(merged.RootAccess, m.RootAccess)
+	}
+
+	if *merged.RootAccess {
+		*merged.ManageAllContainers = true
+		*merged.ManageEndpoints = true
+		*merged.ManageOwnS3Credentials = true
+		*merged.ManageOwnContainerObjects = true
+		*merged.ViewAllContainers = true
+	}
+
+	return merged
+}
+
+// Allowed reports whether the user's groups allow the S3 action on the resource.
+// Resources may be given as ARNs ("arn:aws:s3:::bucket/key"), StorageGRID URNs or plain "bucket/key".
+func (e *Evaluator) Allowed(user *models.User, action string, resource string) bool {
+	return e.Evaluate(user, action, resource).Effect == Allow
+}
+
+// Evaluate evaluates the S3 action on the resource against the S3 policies of the user's groups.
+// An explicit Deny takes precedence over any Allow.
+func (e *Evaluator) Evaluate(user *models.User, action string, resource string) Decision {
+	decision := Decision{Effect: ImplicitDeny, Statement: -1}
+
+	for _, group := range e.GroupsOf(user) {
+		if group.Policies == nil || group.Policies.S3 == nil {
+			continue
+		}
+
+		for i, statement := range group.Policies.S3.Statement {
+			if !statementMatches(statement, action, resource) {
+				continue
+			}
+
+			conditional := statement.Condition != nil && len(*statement.Condition) > 0
+
+			switch statement.Effect {
+			case string(Deny):
+				if conditional {
+					decision.Conditional = true
+					continue
+				}
+				return Decision{Effect: Deny, Group: group.UniqueName, Statement: i}
+			case string(Allow):
+				// keep the first unconditional allow, an unconditional allow beats a conditional one
+				if decision.Effect != Allow || (decision.Conditional && !conditional) {
+					decision.Effect = Allow
+					decision.Group = group.UniqueName
+					decision.Statement = i
+					decision.Conditional = conditional
+				}
+			}
+		}
+	}
+
+	return decision
+}
+
+// WhoCan returns the users that are allowed to perform the S3 action on the resource.
+func (e *Evaluator) WhoCan(users []models.User, action string, resource string) []models.User {
+	allowed := []models.User{}
+	for _, user := range users {
+		if e.Allowed(&user, action, resource) {
+			allowed = append(allowed, user)
+		}
+	}
+
+	return allowed
+}
+
+func statementMatches(statement models.S3Statement, action string, resource string) bool {
+	switch {
+	case statement.Action != nil:
+		if !matchAny(*statement.Action, action, matchAction) {
+			return false
+		}
+	case statement.NotAction != nil:
+		if matchAny(*statement.NotAction, action, matchAction) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	switch {
+	case statement.Resource != nil:
+		return matchAny(statement.Resource, resource, matchResource)
+	case statement.NotResource != nil:
+		return !matchAny(statement.NotResource, resource, matchResource)
+	default:
+		return false
+	}
+}
+
+func mergeBool(merged *bool, value *bool) {
+	if value != nil && *value {
+		*merged = true
+	}
+}
@@ -0,0 +1,151 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func group(id string, s3 *models.S3Policy) models.TenantGroup {
+	return models.TenantGroup{Id: &id, UniqueName: "group/" + id, Policies: &models.TenantGroupPolicies{S3: s3}}
+}
+
+func TestEvaluate(t *testing.T) {
+	groups := []models.TenantGroup{
+		group("readers", NewPolicy().
+			Allow("s3:GetObject", "s3:ListBucket").On("arn:aws:s3:::data", "arn:aws:s3:::data/*").
+			Policy()),
+		group("no-secrets", NewPolicy().
+			Deny("s3:*").On("arn:aws:s3:::data/secret/*").
+			Policy()),
+		group("writers", NewPolicy().
+			Allow("s3:Put*").On("urn:sgws:s3:::data/uploads/*").
+			Policy()),
+		group("all-but-delete", NewPolicy().
+			AllowAllExcept("s3:DeleteObject", "s3:DeleteBucket").On("arn:aws:s3:::scratch/*").
+			Policy()),
+		group("all-but-archive", NewPolicy().
+			Allow("s3:GetObject").NotOn("arn:aws:s3:::archive/*").
+			Policy()),
+		group("office", NewPolicy().
+			Allow("s3:DeleteObject").On("arn:aws:s3:::data/*").When("IpAddress", "aws:SourceIp", "10.0.0.0/8", "192.168.0.0/16").
+			Policy()),
+		group("deny-outside", NewPolicy().
+			Deny("s3:GetObject").On("arn:aws:s3:::data/*").When("NotIpAddress", "aws:SourceIp", "10.0.0.0/8").
+			Policy()),
+		group("single-char", NewPolicy().
+			Allow("s3:GetObject").On("arn:aws:s3:::logs/2024-0?.log").
+			Policy()),
+	}
+	evaluator := NewEvaluator(groups)
+
+	tests := []struct {
+		name        string
+		memberOf    []string
+		action      string
+		resource    string
+		effect      Effect
+		group       string
+		conditional bool
+	}{
+		{"allow matching action and resource", []string{"readers"}, "s3:GetObject", "data/report.csv", Allow, "group/readers", false},
+		{"actions are case-insensitive", []string{"readers"}, "S3:getobject", "arn:aws:s3:::data/report.csv", Allow, "group/readers", false},
+		{"resources are case-sensitive", []string{"readers"}, "s3:GetObject", "Data/report.csv", ImplicitDeny, "", false},
+		{"bucket resource", []string{"readers"}, "s3:ListBucket", "data", Allow, "group/readers", false},
+		{"no statement matches", []string{"readers"}, "s3:PutObject", "data/report.csv", ImplicitDeny, "", false},
+		{"no groups", nil, "s3:GetObject", "data/report.csv", ImplicitDeny, "", false},
+		{"unknown groups are ignored", []string{"missing"}, "s3:GetObject", "data/report.csv", ImplicitDeny, "", false},
+		{"deny overrides allow", []string{"readers", "no-secrets"}, "s3:GetObject", "data/secret/key", Deny, "group/no-secrets", false},
+		{"deny overrides allow regardless of group order", []string{"no-secrets", "readers"}, "s3:GetObject", "data/secret/key", Deny, "group/no-secrets", false},
+		{"deny only applies to its resources", []string{"readers", "no-secrets"}, "s3:GetObject", "data/public/key", Allow, "group/readers", false},
+		{"action wildcard", []string{"writers"}, "s3:PutObjectTagging", "data/uploads/a", Allow, "group/writers", false},
+		{"urn resources match arns", []string{"writers"}, "s3:PutObject", "arn:aws:s3:::data/uploads/a", Allow, "group/writers", false},
+		{"resource wildcard does not match the prefix", []string{"writers"}, "s3:PutObject", "data/uploads", ImplicitDeny, "", false},
+		{"not action allows other actions", []string{"all-but-delete"}, "s3:PutObject", "scratch/a", Allow, "group/all-but-delete", false},
+		{"not action excludes its actions", []string{"all-but-delete"}, "s3:DeleteObject", "scratch/a", ImplicitDeny, "", false},
+		{"not resource allows other resources", []string{"all-but-archive"}, "s3:GetObject", "data/a", Allow, "group/all-but-archive", false},
+		{"not resource excludes its resources", []string{"all-but-archive"}, "s3:GetObject", "archive/a", ImplicitDeny, "", false},
+		{"conditional allow is assumed to apply", []string{"office"}, "s3:DeleteObject", "data/a", Allow, "group/office", true},
+		{"unconditional allow beats conditional allow", []string{"office", "all-but-archive", "readers"}, "s3:GetObject", "data/a", Allow, "group/all-but-archive", false},
+		{"conditional deny is assumed not to apply", []string{"readers", "deny-outside"}, "s3:GetObject", "data/a", Allow, "group/readers", true},
+		{"single character wildcard", []string{"single-char"}, "s3:GetObject", "logs/2024-03.log", Allow, "group/single-char", false},
+		{"single character wildcard matches one character", []string{"single-char"}, "s3:GetObject", "logs/2024-123.log", ImplicitDeny, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &models.User{UniqueName: "user/test", MemberOf: tt.memberOf}
+			decision := evaluator.Evaluate(user, tt.action, tt.resource)
+
+			if decision.Effect != tt.effect || decision.Group != tt.group || decision.Conditional != tt.conditional {
+				t.Errorf("Expected %s by %q (conditional %t), got %s by %q (conditional %t)",
+					tt.effect, tt.group, tt.conditional, decision.Effect, decision.Group, decision.Conditional)
+			}
+			if decision.Effect == ImplicitDeny && decision.Statement != -1 {
+				t.Errorf("Expected statement -1 for an implicit deny, got %d", decision.Statement)
+			}
+		})
+	}
+}
+
+func TestManagement(t *testing.T) {
+	yes, no := true, false
+	readOnly, endpoints := "read-only", "endpoints"
+	groups := []models.TenantGroup{
+		{Id: &readOnly, Policies: &models.TenantGroupPolicies{Management: &models.TenantGroupManagementPolicy{ViewAllContainers: &yes, RootAccess: &no}}},
+		{Id: &endpoints, Policies: &models.TenantGroupPolicies{Management: &models.TenantGroupManagementPolicy{ManageEndpoints: &yes}}},
+	}
+
+	merged := NewEvaluator(groups).Management(&models.User{MemberOf: []string{readOnly, endpoints}})
+
+	if !*merged.ViewAllContainers || !*merged.ManageEndpoints {
+		t.Errorf("Expected permissions granted by any group to be merged, got %+v", merged)
+	}
+	if *merged.RootAccess || *merged.ManageAllContainers {
+		t.Errorf("Expected permissions granted by no group to be denied, got %+v", merged)
+	}
+}
+
+func TestManagement_RootAccess(t *testing.T) {
+	yes := true
+	admins := "admins"
+	groups := []models.TenantGroup{
+		{Id: &admins, Policies: &models.TenantGroupPolicies{Management: &models.TenantGroupManagementPolicy{RootAccess: &yes}}},
+	}
+
+	merged := NewEvaluator(groups).Management(&models.User{MemberOf: []string{admins}})
+
+	granted := []*bool{merged.RootAccess, merged.ManageAllContainers, merged.ManageEndpoints, merged.ManageOwnS3Credentials, merged.ManageOwnContainerObjects, merged.ViewAllContainers}
+	for i, permission := range granted {
+		if !*permission {
+			t.Errorf("Expected root access to grant every management permission, permission %d is denied in %+v", i, merged)
+		}
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"a*", "a", true},
+		{"a*c", "abbbc", true},
+		{"a*c", "abbbd", false},
+		{"a*b*c", "aXbYbZc", true},
+		{"a?c", "abc", true},
+		{"a?c", "ac", false},
+		{"a?c", "abbc", false},
+		{"abc", "abcd", false},
+		{"*.log", "dir/file.log", true},
+		{"**", "x", true},
+	}
+
+	for _, tt := range tests {
+		if got := wildcardMatch(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("wildcardMatch(%q, %q) = %t, want %t", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package policy
+
+import "strings"
+
+const (
+	awsS3ArnPrefix   = "arn:aws:s3:::"
+	sgwsS3UrnPrefix  = "urn:sgws:s3:::"
+	wildcardAny      = '*'
+	wildcardSingular = '?'
+)
+
+// normalizeResource turns bucket/key notation and StorageGRID URNs into AWS ARNs so they can be compared
+func normalizeResource(resource string) string {
+	switch {
+	case strings.HasPrefix(resource, awsS3ArnPrefix):
+		return resource
+	case strings.HasPrefix(resource, sgwsS3UrnPrefix):
+		return awsS3ArnPrefix + strings.TrimPrefix(resource, sgwsS3UrnPrefix)
+	case resource == "*":
+		return resource
+	default:
+		return awsS3ArnPrefix + resource
+	}
+}
+
+// matchAction compares an action against a pattern. Actions are case-insensitive.
+func matchAction(pattern string, action string) bool {
+	return wildcardMatch(strings.ToLower(pattern), strings.ToLower(action))
+}
+
+// matchResource compares a resource against a pattern. Resources are case-sensitive.
+func matchResource(pattern string, resource string) bool {
+	if pattern == "*" {
+		return true
+	}
+
+	return wildcardMatch(normalizeResource(pattern), normalizeResource(resource))
+}
+
+func matchAny(patterns []string, value string, match func(string, string) bool) bool {
+	for _, pattern := range patterns {
+		if match(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wildcardMatch matches value against pattern, where * matches any sequence of characters and ? matches a single character
+func wildcardMatch(pattern string, value string) bool {
+	p, v := 0, 0
+	star, mark := -1, 0
+
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == wildcardSingular || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == wildcardAny:
+			star = p
+			mark = v
+			p++
+		case star != -1:
+			p = star + 1
+			mark++
+			v = mark
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == wildcardAny {
+		p++
+	}
+
+	return p == len(pattern)
+}
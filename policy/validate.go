@@ -0,0 +1,350 @@
+package policy
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// SupportedActions lists the S3 actions StorageGRID accepts in group and bucket policies.
+var SupportedActions = []string{
+	// bucket actions
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketMetadataNotification",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteReplicationConfiguration",
+	"s3:GetBucketAcl",
+	"s3:GetBucketCompliance",
+	"s3:GetBucketConsistency",
+	"s3:GetBucketCORS",
+	"s3:GetEncryptionConfiguration",
+	"s3:GetBucketLastAccessTime",
+	"s3:GetBucketLocation",
+	"s3:GetBucketMetadataNotification",
+	"s3:GetBucketNotification",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:GetBucketPolicy",
+	"s3:GetBucketTagging",
+	"s3:GetBucketVersioning",
+	"s3:GetLifecycleConfiguration",
+	"s3:GetReplicationConfiguration",
+	"s3:ListAllMyBuckets",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+	"s3:ListBucketVersions",
+	"s3:PutBucketCompliance",
+	"s3:PutBucketConsistency",
+	"s3:PutBucketCORS",
+	"s3:PutEncryptionConfiguration",
+	"s3:PutBucketLastAccessTime",
+	"s3:PutBucketMetadataNotification",
+	"s3:PutBucketNotification",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutBucketPolicy",
+	"s3:PutBucketTagging",
+	"s3:PutBucketVersioning",
+	"s3:PutLifecycleConfiguration",
+	"s3:PutReplicationConfiguration",
+	// object actions
+	"s3:AbortMultipartUpload",
+	"s3:BypassGovernanceRetention",
+	"s3:DeleteObject",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersion",
+	"s3:DeleteObjectVersionTagging",
+	"s3:GetObject",
+	"s3:GetObjectAcl",
+	"s3:GetObjectLegalHold",
+	"s3:GetObjectRetention",
+	"s3:GetObjectTagging",
+	"s3:GetObjectVersion",
+	"s3:GetObjectVersionAcl",
+	"s3:GetObjectVersionTagging",
+	"s3:ListMultipartUploadParts",
+	"s3:PutObject",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionTagging",
+	"s3:PutOverwriteObject",
+	"s3:RestoreObject",
+}
+
+// SupportedConditionKeys lists the condition keys StorageGRID accepts. Keys ending in "/" take a tag key suffix.
+var SupportedConditionKeys = []string{
+	"aws:CurrentTime",
+	"aws:EpochTime",
+	"aws:PrincipalType",
+	"aws:SecureTransport",
+	"aws:SourceIp",
+	"aws:UserAgent",
+	"aws:username",
+	"s3:delimiter",
+	"s3:ExistingObjectTag/",
+	"s3:max-keys",
+	"s3:object-lock-legal-hold",
+	"s3:object-lock-mode",
+	"s3:object-lock-remaining-retention-days",
+	"s3:object-lock-retain-until-date",
+	"s3:prefix",
+	"s3:RequestObjectTag/",
+	"s3:RequestObjectTagKeys",
+	"s3:VersionId",
+}
+
+// SupportedConditionOperators lists the condition operators StorageGRID accepts, without the IfExists suffix
+// and the ForAnyValue/ForAllValues set qualifiers.
+var SupportedConditionOperators = []string{
+	"StringEquals",
+	"StringNotEquals",
+	"StringEqualsIgnoreCase",
+	"StringNotEqualsIgnoreCase",
+	"StringLike",
+	"StringNotLike",
+	"NumericEquals",
+	"NumericNotEquals",
+	"NumericGreaterThan",
+	"NumericGreaterThanEquals",
+	"NumericLessThan",
+	"NumericLessThanEquals",
+	"DateEquals",
+	"DateNotEquals",
+	"DateGreaterThan",
+	"DateGreaterThanEquals",
+	"DateLessThan",
+	"DateLessThanEquals",
+	"Bool",
+	"IpAddress",
+	"NotIpAddress",
+	"Null",
+}
+
+var supportedVersions = []string{"2012-10-17", "2008-10-17"}
+
+// ValidationError is a single problem found in a policy. Statement is -1 for policy-level problems.
+type ValidationError struct {
+	Statement int
+	Field     string
+	Message   string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Statement < 0 {
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+
+	return fmt.Sprintf("statement %d: %s: %s", e.Statement, e.Field, e.Message)
+}
+
+// ValidationErrors collects every problem found in a policy.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return "invalid s3 policy: " + strings.Join(messages, "; ")
+}
+
+// Validate checks a policy against the effects, actions, resources and conditions supported by StorageGRID.
+// It reports all problems at once as ValidationErrors, or nil if the policy is valid.
+func Validate(p *models.S3Policy) error {
+	errs := ValidationErrors{}
+	add := func(statement int, field string, format string, args ...interface{}) {
+		errs = append(errs, &ValidationError{Statement: statement, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if p == nil {
+		add(-1, "Policy", "must not be nil")
+		return errs
+	}
+
+	if p.Version != nil && !slices.Contains(supportedVersions, *p.Version) {
+		add(-1, "Version", "unsupported version %q", *p.Version)
+	}
+
+	if len(p.Statement) == 0 {
+		add(-1, "Statement", "at least one statement is required")
+	}
+
+	sids := map[string]int{}
+	for i, statement := range p.Statement {
+		if statement.Sid != "" {
+			if first, ok := sids[statement.Sid]; ok {
+				add(i, "Sid", "duplicate sid %q, first used by statement %d", statement.Sid, first)
+			} else {
+				sids[statement.Sid] = i
+			}
+		}
+
+		if statement.Effect != string(Allow) && statement.Effect != string(Deny) {
+			add(i, "Effect", "must be %q or %q, got %q", Allow, Deny, statement.Effect)
+		}
+
+		validateActions(i, statement, add)
+		validateResources(i, statement, add)
+		validateConditions(i, statement, add)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func validateActions(i int, statement models.S3Statement, add func(int, string, string, ...interface{})) {
+	field, actions := "Action", statement.Action
+	switch {
+	case statement.Action != nil && statement.NotAction != nil:
+		add(i, "Action", "Action and NotAction are mutually exclusive")
+		return
+	case statement.NotAction != nil:
+		field, actions = "NotAction", statement.NotAction
+	case statement.Action == nil:
+		add(i, "Action", "either Action or NotAction is required")
+		return
+	}
+
+	if len(*actions) == 0 {
+		add(i, field, "must not be empty")
+	}
+
+	for _, action := range *actions {
+		if action == "*" {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(action), "s3:") {
+			add(i, field, "action %q must start with s3:", action)
+			continue
+		}
+		if !matchAny(SupportedActions, action, func(supported string, pattern string) bool { return matchAction(pattern, supported) }) {
+			add(i, field, "action %q is not supported by StorageGRID", action)
+		}
+	}
+}
+
+func validateResources(i int, statement models.S3Statement, add func(int, string, string, ...interface{})) {
+	field, resources := "Resource", statement.Resource
+	switch {
+	case statement.Resource != nil && statement.NotResource != nil:
+		add(i, "Resource", "Resource and NotResource are mutually exclusive")
+		return
+	case statement.NotResource != nil:
+		field, resources = "NotResource", statement.NotResource
+	case statement.Resource == nil:
+		add(i, "Resource", "either Resource or NotResource is required")
+		return
+	}
+
+	if len(resources) == 0 {
+		add(i, field, "must not be empty")
+	}
+
+	for _, resource := range resources {
+		if resource == "*" {
+			continue
+		}
+
+		var bucket string
+		switch {
+		case strings.HasPrefix(resource, awsS3ArnPrefix):
+			bucket = strings.TrimPrefix(resource, awsS3ArnPrefix)
+		case strings.HasPrefix(resource, sgwsS3UrnPrefix):
+			bucket = strings.TrimPrefix(resource, sgwsS3UrnPrefix)
+		default:
+			add(i, field, "resource %q must start with %s or %s", resource, awsS3ArnPrefix, sgwsS3UrnPrefix)
+			continue
+		}
+
+		if bucket == "" || strings.HasPrefix(bucket, "/") {
+			add(i, field, "resource %q is missing a bucket name", resource)
+		}
+	}
+}
+
+func validateConditions(i int, statement models.S3Statement, add func(int, string, string, ...interface{})) {
+	if statement.Condition == nil {
+		return
+	}
+
+	for _, operator := range slices.Sorted(maps.Keys(*statement.Condition)) {
+		conditions := (*statement.Condition)[operator]
+		base := strings.TrimPrefix(strings.TrimPrefix(operator, "ForAnyValue:"), "ForAllValues:")
+		base = strings.TrimSuffix(base, "IfExists")
+		if !slices.Contains(SupportedConditionOperators, base) {
+			add(i, "Condition", "operator %q is not supported by StorageGRID", operator)
+			continue
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(conditions)) {
+			values := conditions[key]
+			if !supportedConditionKey(key) {
+				add(i, "Condition", "condition key %q is not supported by StorageGRID", key)
+			}
+
+			if len(values) == 0 {
+				add(i, "Condition", "%s %s requires at least one value", operator, key)
+			}
+
+			for _, value := range values {
+				if msg := checkConditionValue(base, value); msg != "" {
+					add(i, "Condition", "%s %s: %s", operator, key, msg)
+				}
+			}
+		}
+	}
+}
+
+func supportedConditionKey(key string) bool {
+	for _, supported := range SupportedConditionKeys {
+		if strings.HasSuffix(supported, "/") {
+			if strings.HasPrefix(key, supported) && len(key) > len(supported) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(supported, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkConditionValue returns a message if the value cannot be used with the operator
+func checkConditionValue(operator string, value string) string {
+	switch {
+	case strings.HasPrefix(operator, "Numeric"):
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("value %q is not a number", value)
+		}
+	case strings.HasPrefix(operator, "Date"):
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				return fmt.Sprintf("value %q is not an RFC 3339 date or epoch time", value)
+			}
+		}
+	case operator == "Bool" || operator == "Null":
+		if value != "true" && value != "false" {
+			return fmt.Sprintf("value %q is not a boolean", value)
+		}
+	case operator == "IpAddress" || operator == "NotIpAddress":
+		if _, err := netip.ParsePrefix(value); err != nil {
+			if _, err := netip.ParseAddr(value); err != nil {
+				return fmt.Sprintf("value %q is not an IP address or CIDR", value)
+			}
+		}
+	}
+
+	return ""
+}
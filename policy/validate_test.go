@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func TestValidate(t *testing.T) {
+	version := "2012-10-17"
+	unsupportedVersion := "2020-01-01"
+
+	tests := []struct {
+		name   string
+		policy *models.S3Policy
+		// errors lists a substring of every expected problem, an empty list expects a valid policy
+		errors []string
+	}{
+		{
+			name:   "valid policy",
+			policy: NewPolicy().Allow("s3:GetObject", "s3:List*").On("arn:aws:s3:::data", "urn:sgws:s3:::data/*").Policy(),
+		},
+		{
+			name: "valid multi-value and qualified conditions",
+			policy: NewPolicy().
+				Allow("s3:PutObject").On("data/*").
+				When("IpAddress", "aws:SourceIp", "10.0.0.0/8", "192.0.2.1").
+				When("ForAnyValue:StringLike", "s3:RequestObjectTagKeys", "team", "project*").
+				When("NumericLessThanEqualsIfExists", "s3:max-keys", "100").
+				When("DateGreaterThan", "aws:CurrentTime", "2024-01-01T00:00:00Z").
+				When("StringEquals", "s3:ExistingObjectTag/team", "storage").
+				Policy(),
+		},
+		{
+			name:   "valid not action and not resource",
+			policy: NewPolicy().DenyAllExcept("s3:GetObject").NotOn("data/public/*").Policy(),
+		},
+		{
+			name:   "nil policy",
+			policy: nil,
+			errors: []string{"must not be nil"},
+		},
+		{
+			name:   "no statements",
+			policy: &models.S3Policy{Version: &unsupportedVersion},
+			errors: []string{`unsupported version "2020-01-01"`, "at least one statement is required"},
+		},
+		{
+			name: "invalid effect and duplicate sid",
+			policy: &models.S3Policy{Version: &version, Statement: []models.S3Statement{
+				{Sid: "a", Effect: "allow", Action: &[]string{"s3:GetObject"}, Resource: []string{"*"}},
+				{Sid: "a", Effect: "Allow", Action: &[]string{"s3:GetObject"}, Resource: []string{"*"}},
+			}},
+			errors: []string{`must be "Allow" or "Deny", got "allow"`, `duplicate sid "a"`},
+		},
+		{
+			name:   "unsupported actions",
+			policy: NewPolicy().Allow("s3:GetObjects", "iam:CreateUser", "s3:Frobnicate*").On("*").Policy(),
+			errors: []string{`"s3:GetObjects" is not supported`, `"iam:CreateUser" must start with s3:`, `"s3:Frobnicate*" is not supported`},
+		},
+		{
+			name: "action and not action",
+			policy: &models.S3Policy{Statement: []models.S3Statement{
+				{Effect: "Allow", Action: &[]string{"s3:GetObject"}, NotAction: &[]string{"s3:PutObject"}, Resource: []string{"*"}},
+			}},
+			errors: []string{"Action and NotAction are mutually exclusive"},
+		},
+		{
+			name: "missing action and resource",
+			policy: &models.S3Policy{Statement: []models.S3Statement{
+				{Effect: "Allow"},
+			}},
+			errors: []string{"either Action or NotAction is required", "either Resource or NotResource is required"},
+		},
+		{
+			name: "resource and not resource",
+			policy: &models.S3Policy{Statement: []models.S3Statement{
+				{Effect: "Deny", Action: &[]string{"s3:*"}, Resource: []string{"*"}, NotResource: []string{"*"}},
+			}},
+			errors: []string{"Resource and NotResource are mutually exclusive"},
+		},
+		{
+			name: "invalid resources",
+			policy: &models.S3Policy{Statement: []models.S3Statement{
+				{Effect: "Allow", Action: &[]string{"s3:GetObject"}, Resource: []string{"data/*", "arn:aws:s3:::", "urn:sgws:s3:::/key"}},
+			}},
+			errors: []string{`"data/*" must start with`, `"arn:aws:s3:::" is missing a bucket name`, `"urn:sgws:s3:::/key" is missing a bucket name`},
+		},
+		{
+			name: "invalid conditions",
+			policy: NewPolicy().
+				Allow("s3:GetObject").On("data/*").
+				When("StringMatches", "aws:username", "alice").
+				When("StringEquals", "aws:unknown", "x").
+				When("StringEquals", "s3:ExistingObjectTag/").
+				When("NumericEquals", "s3:max-keys", "10", "ten").
+				When("DateLessThan", "aws:CurrentTime", "yesterday").
+				When("Bool", "aws:SecureTransport", "yes").
+				When("NotIpAddress", "aws:SourceIp", "10.0.0.0/8", "10.0.0.300").
+				Policy(),
+			errors: []string{
+				`operator "StringMatches" is not supported`,
+				`condition key "aws:unknown" is not supported`,
+				`condition key "s3:ExistingObjectTag/" is not supported`,
+				"StringEquals s3:ExistingObjectTag/ requires at least one value",
+				`value "ten" is not a number`,
+				`value "yesterday" is not an RFC 3339 date`,
+				`value "yes" is not a boolean`,
+				`value "10.0.0.300" is not an IP address`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.policy)
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Fatalf("Expected a valid policy, got %v", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+			if len(errs) != len(tt.errors) {
+				t.Errorf("Expected %d problems, got %d: %v", len(tt.errors), len(errs), err)
+			}
+			for _, want := range tt.errors {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected a problem containing %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBuild_Invalid(t *testing.T) {
+	p, err := NewPolicy().Allow("s3:GetObject").Build()
+	if err == nil || p != nil {
+		t.Fatalf("Expected a statement without resources to fail, got %v", p)
+	}
+}
@@ -0,0 +1,275 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// defaultCapabilities are given to tenants created without capabilities
+var defaultCapabilities = []string{"management", "s3"}
+
+// applier carries the state of a single Apply
+type applier struct {
+	reconciler *Reconciler
+	accounts   map[string]*models.Tenant
+	clients    map[string]Tenant
+}
+
+// Apply executes the changes of a plan in order and stops at the first error.
+// Changes are not validated again, so plans should be applied soon after they were made.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	a := &applier{
+		reconciler: r,
+		accounts:   maps.Clone(plan.accounts),
+		clients:    maps.Clone(plan.clients),
+	}
+	if a.accounts == nil {
+		a.accounts = map[string]*models.Tenant{}
+	}
+	if a.clients == nil {
+		a.clients = map[string]Tenant{}
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+
+		var err error
+		switch change.Kind {
+		case KindTenant:
+			err = a.applyTenant(ctx, change)
+		case KindGroup:
+			err = a.applyGroup(ctx, change)
+		case KindUser:
+			err = a.applyUser(ctx, change)
+		case KindBucket:
+			err = a.applyBucket(ctx, change)
+		default:
+			err = fmt.Errorf("unknown kind %s", change.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s %s: %w", change.Action, change.address(), err)
+		}
+	}
+
+	return nil
+}
+
+func (a *applier) applyTenant(ctx context.Context, change *Change) error {
+	tenants := a.reconciler.grid.Tenant()
+
+	switch change.Action {
+	case ActionCreate:
+		tenant := &models.Tenant{Name: &change.tenant.Name, Capabilities: defaultCapabilities, Policy: &models.TenantPolicy{}}
+		applyTenantSpec(tenant, change.tenant)
+		tenant.Password = change.tenant.RootPassword
+
+		created, err := tenants.Create(ctx, tenant)
+		if err != nil {
+			return err
+		}
+		a.accounts[change.Name] = created
+	case ActionUpdate:
+		tenant := *change.live.(*models.Tenant)
+		applyTenantSpec(&tenant, change.tenant)
+
+		updated, err := tenants.Update(ctx, &tenant)
+		if err != nil {
+			return err
+		}
+		a.accounts[change.Name] = updated
+	case ActionDelete:
+		return tenants.Delete(ctx, change.live.(*models.Tenant).Id)
+	}
+
+	return nil
+}
+
+func (a *applier) applyGroup(ctx context.Context, change *Change) error {
+	tenantClient, err := a.client(ctx, change)
+	if err != nil {
+		return err
+	}
+	groups := tenantClient.Groups()
+
+	switch change.Action {
+	case ActionCreate:
+		group := &models.TenantGroup{UniqueName: change.group.UniqueName, DisplayName: shortName(change.group.UniqueName)}
+		applyGroupSpec(group, change.group)
+		_, err = groups.Create(ctx, group)
+	case ActionUpdate:
+		group := *change.live.(*models.TenantGroup)
+		applyGroupSpec(&group, change.group)
+		_, err = groups.Update(ctx, &group)
+	case ActionDelete:
+		err = groups.Delete(ctx, models.Deref(change.live.(*models.TenantGroup).Id))
+	}
+
+	return err
+}
+
+func (a *applier) applyUser(ctx context.Context, change *Change) error {
+	tenantClient, err := a.client(ctx, change)
+	if err != nil {
+		return err
+	}
+	users := tenantClient.Users()
+
+	if change.Action == ActionDelete {
+		return users.Delete(ctx, models.Deref(change.live.(*models.User).Id))
+	}
+
+	var memberOf []string
+	if change.user.MemberOf != nil {
+		memberOf, err = tenantClient.Groups().ResolveIds(ctx, change.user.MemberOf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if change.Action == ActionUpdate {
+		user := *change.live.(*models.User)
+		applyUserSpec(&user, change.user, memberOf)
+		_, err = users.Update(ctx, &user)
+		return err
+	}
+
+	user := &models.User{UniqueName: change.user.UniqueName, FullName: change.user.FullName}
+	if user.FullName == nil {
+		fullName := shortName(change.user.UniqueName)
+		user.FullName = &fullName
+	}
+	applyUserSpec(user, change.user, memberOf)
+
+	created, err := users.Create(ctx, user)
+	if err != nil {
+		return err
+	}
+	if change.user.Password != nil {
+		return users.SetPassword(ctx, models.Deref(created.Id), *change.user.Password)
+	}
+
+	return nil
+}
+
+func (a *applier) applyBucket(ctx context.Context, change *Change) error {
+	// Plan rejects bucket updates, a plan never contains them
+	if change.Action == ActionUpdate {
+		return ErrImmutable
+	}
+
+	tenantClient, err := a.client(ctx, change)
+	if err != nil {
+		return err
+	}
+	buckets := tenantClient.Bucket()
+
+	if change.Action == ActionDelete {
+		return buckets.Delete(ctx, change.Name)
+	}
+
+	bucket := &models.Bucket{Name: change.bucket.Name}
+	applyBucketSpec(bucket, change.bucket)
+	_, err = buckets.Create(ctx, bucket)
+
+	return err
+}
+
+// client returns the client of the tenant of a change, connecting to tenants created by the plan
+func (a *applier) client(ctx context.Context, change *Change) (Tenant, error) {
+	if tenantClient, ok := a.clients[change.Tenant]; ok {
+		return tenantClient, nil
+	}
+
+	account, ok := a.accounts[change.Tenant]
+	if !ok {
+		return nil, fmt.Errorf("tenant %s does not exist", change.Tenant)
+	}
+
+	tenantClient, err := a.reconciler.connect(ctx, account, change.tenant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to tenant %s: %w", change.Tenant, err)
+	}
+	a.clients[change.Tenant] = tenantClient
+
+	return tenantClient, nil
+}
+
+// applyTenantSpec sets the managed fields of the tenant, copying the policy as it may be shared with the live state.
+// The policy is left alone if the spec manages none of its fields.
+func applyTenantSpec(tenant *models.Tenant, spec *TenantSpec) {
+	if spec.Description != nil {
+		tenant.Description = spec.Description
+	}
+	if spec.Capabilities != nil {
+		tenant.Capabilities = spec.Capabilities
+	}
+
+	if spec.QuotaObjectBytes == nil && spec.AllowPlatformServices == nil && spec.AllowSelectObjectContent == nil && spec.UseAccountIdentitySource == nil {
+		return
+	}
+
+	policy := models.TenantPolicy{}
+	if tenant.Policy != nil {
+		policy = *tenant.Policy
+	}
+	if spec.QuotaObjectBytes != nil {
+		policy.QuotaObjectBytes = spec.QuotaObjectBytes
+	}
+	if spec.AllowPlatformServices != nil {
+		policy.AllowPlatformServices = *spec.AllowPlatformServices
+	}
+	if spec.AllowSelectObjectContent != nil {
+		policy.AllowSelectObjectContent = spec.AllowSelectObjectContent
+	}
+	if spec.UseAccountIdentitySource != nil {
+		policy.UseAccountIdentitySource = *spec.UseAccountIdentitySource
+	}
+
+	tenant.Policy = &policy
+}
+
+func applyGroupSpec(group *models.TenantGroup, spec *GroupSpec) {
+	if spec.DisplayName != nil {
+		group.DisplayName = *spec.DisplayName
+	}
+	if spec.ManagementReadOnly != nil {
+		group.ManagementReadOnly = spec.ManagementReadOnly
+	}
+	if spec.Policies != nil {
+		group.Policies = spec.Policies
+	}
+}
+
+// applyUserSpec sets the managed fields of the user, memberOf holds the resolved group IDs
+func applyUserSpec(user *models.User, spec *UserSpec, memberOf []string) {
+	if spec.FullName != nil {
+		user.FullName = spec.FullName
+	}
+	if spec.MemberOf != nil {
+		user.MemberOf = memberOf
+	}
+	if spec.Disable != nil {
+		user.Disable = spec.Disable
+	}
+}
+
+func applyBucketSpec(bucket *models.Bucket, spec *BucketSpec) {
+	if spec.Region != nil {
+		bucket.Region = *spec.Region
+	}
+	if spec.EnableVersioning != nil {
+		bucket.EnableVersioning = spec.EnableVersioning
+	}
+	if spec.S3ObjectLock != nil {
+		bucket.S3ObjectLock = spec.S3ObjectLock
+	}
+}
+
+// shortName strips the prefix of a unique name
+func shortName(uniqueName string) string {
+	return uniqueName[strings.LastIndex(uniqueName, "/")+1:]
+}
@@ -0,0 +1,159 @@
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// Action is what a change does to a resource
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Kind is the type of resource a change applies to
+type Kind string
+
+const (
+	KindTenant Kind = "tenant"
+	KindGroup  Kind = "group"
+	KindUser   Kind = "user"
+	KindBucket Kind = "bucket"
+)
+
+// Change is a single step of a plan
+type Change struct {
+	Action Action `json:"action"`
+	Kind   Kind   `json:"kind"`
+	// Tenant is the name of the tenant account the resource belongs to
+	Tenant string `json:"tenant"`
+	// Name is the tenant name, the unique name of a group or user, or the bucket name
+	Name string `json:"name"`
+	// Diffs lists the changed fields of updates
+	Diffs []models.FieldChange `json:"diffs,omitempty"`
+
+	tenant *TenantSpec
+	group  *GroupSpec
+	user   *UserSpec
+	bucket *BucketSpec
+	// live is the current resource of updates and deletes
+	live interface{}
+}
+
+// Plan is the ordered list of changes that brings the live state to the spec.
+// Creates and updates come first, parents before children, followed by deletes, children before parents.
+type Plan struct {
+	Changes []Change `json:"changes"`
+
+	// accounts are the live tenant accounts of the spec by name
+	accounts map[string]*models.Tenant
+	// clients are the tenant clients connected while planning by tenant name
+	clients map[string]Tenant
+}
+
+// Empty reports whether the live state already matches the spec
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan with one line per change and one indented line per changed field
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	b := strings.Builder{}
+	counts := map[Action]int{}
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+		b.WriteString("\n")
+		for _, diff := range change.Diffs {
+			fmt.Fprintf(&b, "    %s: %s => %s\n", diff.Path, formatValue(diff.Actual), formatValue(diff.Desired))
+		}
+		counts[change.Action]++
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.\n", counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+
+	return b.String()
+}
+
+// String returns the symbol of the action and the address of the resource, e.g. "+ group acme/group/admins"
+func (c Change) String() string {
+	symbol := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[c.Action]
+
+	return symbol + " " + c.address()
+}
+
+func (c Change) address() string {
+	if c.Kind == KindTenant {
+		return string(c.Kind) + " " + c.Name
+	}
+
+	return string(c.Kind) + " " + c.Tenant + "/" + c.Name
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+func tenantDiffs(live *models.Tenant, spec *TenantSpec) []models.FieldChange {
+	desired := *live
+	applyTenantSpec(&desired, spec)
+
+	return models.DiffTenant(&desired, live)
+}
+
+func groupDiffs(live *models.TenantGroup, spec *GroupSpec) []models.FieldChange {
+	desired := *live
+	applyGroupSpec(&desired, spec)
+
+	return models.DiffTenantGroup(&desired, live)
+}
+
+// userDiffs compares group memberships by unique name and regardless of order, groupNames maps the live group IDs to their names
+func userDiffs(live *models.User, spec *UserSpec, groupNames map[string]string) []models.FieldChange {
+	actual := *live
+	if live.MemberOf != nil {
+		actual.MemberOf = make([]string, 0, len(live.MemberOf))
+		for _, id := range live.MemberOf {
+			name, ok := groupNames[id]
+			if !ok {
+				name = id
+			}
+			actual.MemberOf = append(actual.MemberOf, name)
+		}
+		slices.Sort(actual.MemberOf)
+	}
+
+	var memberOf []string
+	if spec.MemberOf != nil {
+		memberOf = slices.Sorted(slices.Values(spec.MemberOf))
+	}
+	desired := actual
+	applyUserSpec(&desired, spec, memberOf)
+
+	return models.Diff(&desired, &actual)
+}
+
+func bucketDiffs(live *models.Bucket, spec *BucketSpec) []models.FieldChange {
+	desired := *live
+	applyBucketSpec(&desired, spec)
+
+	return models.Diff(&desired, live)
+}
@@ -0,0 +1,352 @@
+// Package reconcile manages tenants and their groups, users and buckets as declarative desired state.
+//
+// A Spec, usually loaded from YAML, is compared with the live state read through the services of the
+// SDK. The result is a Plan of creates, updates and deletes with field-level differences, which can be
+// reviewed and then applied in dependency order:
+//
+//	spec, err := reconcile.LoadSpec("grid.yaml")
+//	if err != nil {
+//		return err
+//	}
+//
+//	r := reconcile.New(gridClient, reconcile.RootConnector(client.FromEnv()), reconcile.WithPrune(reconcile.PruneResources))
+//	plan, err := r.Plan(ctx, spec)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//
+//	err = r.Apply(ctx, plan)
+//
+// Fields left empty in the spec are not managed. Resources missing from the spec are only deleted in
+// the prune modes. The fakegrid package serves the same API, so specs can be tested without a grid.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+// ErrImmutable is returned by Plan when the spec changes fields of a resource that cannot be changed after creation
+var ErrImmutable = errors.New("cannot be changed after creation")
+
+// PruneMode controls which resources missing from the spec are deleted
+type PruneMode int
+
+const (
+	// PruneNone never deletes resources
+	PruneNone PruneMode = iota
+	// PruneResources deletes local groups, users and buckets missing from the tenants of the spec, but no tenants
+	PruneResources
+	// PruneAll additionally deletes tenant accounts missing from the spec. Their buckets have to be deleted first.
+	PruneAll
+)
+
+// Grid is the part of client.GridClient the reconciler needs
+type Grid interface {
+	Tenant() services.TenantServiceInterface
+}
+
+// Tenant is the part of client.TenantClient the reconciler needs
+type Tenant interface {
+	Bucket() services.BucketServiceInterface
+	Users() services.TenantUserServiceInterface
+	Groups() services.TenantGroupServiceInterface
+}
+
+// Connector returns a client signed in to a tenant account with permission to manage its groups, users and buckets
+type Connector func(ctx context.Context, account *models.Tenant, spec *TenantSpec) (Tenant, error)
+
+// Reconciler plans and applies specs against a grid
+type Reconciler struct {
+	grid    Grid
+	connect Connector
+	prune   PruneMode
+}
+
+type Option func(*Reconciler)
+
+// WithPrune sets which resources missing from the spec are deleted, PruneNone by default
+func WithPrune(mode PruneMode) Option {
+	return func(r *Reconciler) {
+		r.prune = mode
+	}
+}
+
+func New(grid Grid, connect Connector, options ...Option) *Reconciler {
+	r := &Reconciler{grid: grid, connect: connect}
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// RootConnector signs in to tenant accounts as their root user with the RootPassword of the spec.
+// The options configure the endpoint and TLS settings and must not set credentials.
+func RootConnector(options ...client.ClientOption) Connector {
+	return func(ctx context.Context, account *models.Tenant, spec *TenantSpec) (Tenant, error) {
+		if spec.RootPassword == nil {
+			return nil, fmt.Errorf("rootPassword is required to connect to tenant %s", spec.Name)
+		}
+
+		credentials := &models.Credentials{Username: models.TenantRootUsername, Password: *spec.RootPassword, AccountId: &account.Id}
+		return client.NewTenantClient(append(options, client.WithCredentials(credentials))...)
+	}
+}
+
+// planner collects the changes of a plan by phase
+type planner struct {
+	plan    *Plan
+	changes map[Kind][]Change
+	deletes map[Kind][]Change
+}
+
+func (p *planner) add(change Change) {
+	if change.Action == ActionDelete {
+		p.deletes[change.Kind] = append(p.deletes[change.Kind], change)
+		return
+	}
+
+	p.changes[change.Kind] = append(p.changes[change.Kind], change)
+}
+
+// Plan compares the spec with the live state and returns the changes needed to reach the spec.
+// Existing tenants are connected to when the spec manages their resources or resources are pruned.
+// A spec that differs from an existing bucket is rejected with an error wrapping ErrImmutable, so that
+// Apply never starts a plan it cannot finish.
+func (r *Reconciler) Plan(ctx context.Context, spec *Spec) (*Plan, error) {
+	spec, err := spec.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := r.grid.Tenant().Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	live := map[string]*models.Tenant{}
+	duplicates := map[string]bool{}
+	for i := range *tenants {
+		tenant := &(*tenants)[i]
+		name := models.Deref(tenant.Name)
+		if _, ok := live[name]; ok {
+			duplicates[name] = true
+		}
+		live[name] = tenant
+	}
+
+	p := &planner{
+		plan:    &Plan{Changes: []Change{}, accounts: map[string]*models.Tenant{}, clients: map[string]Tenant{}},
+		changes: map[Kind][]Change{},
+		deletes: map[Kind][]Change{},
+	}
+
+	managed := map[string]bool{}
+	for i := range spec.Tenants {
+		tenantSpec := &spec.Tenants[i]
+		managed[tenantSpec.Name] = true
+		if duplicates[tenantSpec.Name] {
+			return nil, fmt.Errorf("tenant name %s is not unique on the grid", tenantSpec.Name)
+		}
+
+		account, ok := live[tenantSpec.Name]
+		if !ok {
+			err = p.planNewTenant(tenantSpec)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		p.plan.accounts[tenantSpec.Name] = account
+		if diffs := tenantDiffs(account, tenantSpec); len(diffs) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindTenant, Tenant: tenantSpec.Name, Name: tenantSpec.Name, Diffs: diffs, tenant: tenantSpec, live: account})
+		}
+
+		if len(tenantSpec.Groups) == 0 && len(tenantSpec.Users) == 0 && len(tenantSpec.Buckets) == 0 && r.prune == PruneNone {
+			continue
+		}
+
+		tenantClient, err := r.connect(ctx, account, tenantSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to tenant %s: %w", tenantSpec.Name, err)
+		}
+		p.plan.clients[tenantSpec.Name] = tenantClient
+
+		err = r.planResources(ctx, p, tenantSpec, tenantClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.prune == PruneAll {
+		for i := range *tenants {
+			account := &(*tenants)[i]
+			name := models.Deref(account.Name)
+			if !managed[name] {
+				p.add(Change{Action: ActionDelete, Kind: KindTenant, Tenant: name, Name: name, live: account})
+			}
+		}
+	}
+
+	for _, kind := range []Kind{KindTenant, KindGroup, KindUser, KindBucket} {
+		p.plan.Changes = append(p.plan.Changes, p.changes[kind]...)
+	}
+	for _, kind := range []Kind{KindUser, KindGroup, KindBucket, KindTenant} {
+		p.plan.Changes = append(p.plan.Changes, p.deletes[kind]...)
+	}
+
+	return p.plan, nil
+}
+
+// planNewTenant creates the tenant and all of its resources
+func (p *planner) planNewTenant(spec *TenantSpec) error {
+	p.add(Change{Action: ActionCreate, Kind: KindTenant, Tenant: spec.Name, Name: spec.Name, tenant: spec})
+
+	groups := map[string]bool{}
+	for i := range spec.Groups {
+		group := &spec.Groups[i]
+		groups[group.UniqueName] = true
+		p.add(Change{Action: ActionCreate, Kind: KindGroup, Tenant: spec.Name, Name: group.UniqueName, tenant: spec, group: group})
+	}
+
+	for i := range spec.Users {
+		user := &spec.Users[i]
+		for _, group := range user.MemberOf {
+			if !groups[group] {
+				return fmt.Errorf("tenant %s: user %s is member of unknown group %s", spec.Name, user.UniqueName, group)
+			}
+		}
+		p.add(Change{Action: ActionCreate, Kind: KindUser, Tenant: spec.Name, Name: user.UniqueName, tenant: spec, user: user})
+	}
+
+	for i := range spec.Buckets {
+		bucket := &spec.Buckets[i]
+		p.add(Change{Action: ActionCreate, Kind: KindBucket, Tenant: spec.Name, Name: bucket.Name, tenant: spec, bucket: bucket})
+	}
+
+	return nil
+}
+
+// planResources diffs the groups, users and buckets of an existing tenant
+func (r *Reconciler) planResources(ctx context.Context, p *planner, spec *TenantSpec, tenantClient Tenant) error {
+	groups, err := tenantClient.Groups().Iter().All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list groups of tenant %s: %w", spec.Name, err)
+	}
+	users, err := tenantClient.Users().Iter().All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users of tenant %s: %w", spec.Name, err)
+	}
+	buckets, err := tenantClient.Bucket().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list buckets of tenant %s: %w", spec.Name, err)
+	}
+
+	liveGroups := map[string]*models.TenantGroup{}
+	groupNames := map[string]string{}
+	for i := range *groups {
+		group := &(*groups)[i]
+		liveGroups[group.UniqueName] = group
+		groupNames[models.Deref(group.Id)] = group.UniqueName
+	}
+
+	wanted := map[string]bool{}
+	for i := range spec.Groups {
+		group := &spec.Groups[i]
+		wanted[group.UniqueName] = true
+		live, ok := liveGroups[group.UniqueName]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindGroup, Tenant: spec.Name, Name: group.UniqueName, tenant: spec, group: group})
+			continue
+		}
+		if diffs := groupDiffs(live, group); len(diffs) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindGroup, Tenant: spec.Name, Name: group.UniqueName, Diffs: diffs, tenant: spec, group: group, live: live})
+		}
+	}
+	if r.prune >= PruneResources {
+		for i := range *groups {
+			group := &(*groups)[i]
+			if !wanted[group.UniqueName] && !models.Deref(group.Federated) {
+				p.add(Change{Action: ActionDelete, Kind: KindGroup, Tenant: spec.Name, Name: group.UniqueName, tenant: spec, live: group})
+			}
+		}
+	}
+
+	liveUsers := map[string]*models.User{}
+	for i := range *users {
+		user := &(*users)[i]
+		liveUsers[user.UniqueName] = user
+	}
+
+	wanted = map[string]bool{}
+	for i := range spec.Users {
+		user := &spec.Users[i]
+		wanted[user.UniqueName] = true
+		for _, group := range user.MemberOf {
+			if _, ok := liveGroups[group]; !ok && !slices.ContainsFunc(spec.Groups, func(g GroupSpec) bool { return g.UniqueName == group }) {
+				return fmt.Errorf("tenant %s: user %s is member of unknown group %s", spec.Name, user.UniqueName, group)
+			}
+		}
+
+		live, ok := liveUsers[user.UniqueName]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindUser, Tenant: spec.Name, Name: user.UniqueName, tenant: spec, user: user})
+			continue
+		}
+		if diffs := userDiffs(live, user, groupNames); len(diffs) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindUser, Tenant: spec.Name, Name: user.UniqueName, Diffs: diffs, tenant: spec, user: user, live: live})
+		}
+	}
+	if r.prune >= PruneResources {
+		for i := range *users {
+			user := &(*users)[i]
+			if !wanted[user.UniqueName] && !models.Deref(user.Federated) && user.UniqueName != models.TenantRootUsername {
+				p.add(Change{Action: ActionDelete, Kind: KindUser, Tenant: spec.Name, Name: user.UniqueName, tenant: spec, live: user})
+			}
+		}
+	}
+
+	liveBuckets := map[string]*models.Bucket{}
+	for i := range *buckets {
+		bucket := &(*buckets)[i]
+		liveBuckets[bucket.Name] = bucket
+	}
+
+	wanted = map[string]bool{}
+	for i := range spec.Buckets {
+		bucket := &spec.Buckets[i]
+		wanted[bucket.Name] = true
+		live, ok := liveBuckets[bucket.Name]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindBucket, Tenant: spec.Name, Name: bucket.Name, tenant: spec, bucket: bucket})
+			continue
+		}
+		if diffs := bucketDiffs(live, bucket); len(diffs) > 0 {
+			paths := make([]string, 0, len(diffs))
+			for _, diff := range diffs {
+				paths = append(paths, diff.Path)
+			}
+			return fmt.Errorf("tenant %s: bucket %s: %s %w", spec.Name, bucket.Name, strings.Join(paths, ", "), ErrImmutable)
+		}
+	}
+	if r.prune >= PruneResources {
+		for i := range *buckets {
+			bucket := &(*buckets)[i]
+			if !wanted[bucket.Name] {
+				p.add(Change{Action: ActionDelete, Kind: KindBucket, Tenant: spec.Name, Name: bucket.Name, tenant: spec, live: bucket})
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package reconcile_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/reconcile"
+	"github.com/yehlo/storagegrid-sdk-go/testing/fakegrid"
+)
+
+const rootPassword = "Reconcile-Root-1"
+
+// newGrid starts a fake grid with the tenant acme holding the group old, the user old and the bucket old,
+// and the tenant other, which no spec of the tests manages
+func newGrid(t *testing.T) (*fakegrid.Server, *client.GridClient) {
+	t.Helper()
+
+	grid := fakegrid.New()
+	t.Cleanup(grid.Close)
+
+	gridClient, err := client.NewGridClient(client.WithEndpoint(grid.URL), client.WithCredentials(grid.GridCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create grid client: %v", err)
+	}
+
+	acme := grid.AddTenant("acme", rootPassword)
+	grid.AddTenant("other", rootPassword)
+
+	credentials, err := grid.TenantCredentials(acme.Id)
+	if err != nil {
+		t.Fatalf("Failed to get tenant credentials: %v", err)
+	}
+	tenantClient, err := client.NewTenantClient(client.WithEndpoint(grid.URL), client.WithCredentials(credentials))
+	if err != nil {
+		t.Fatalf("Failed to create tenant client: %v", err)
+	}
+
+	ctx := context.Background()
+	_, err = tenantClient.Groups().Create(ctx, &models.TenantGroup{UniqueName: "group/old", DisplayName: "old"})
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	_, err = tenantClient.Users().Create(ctx, &models.User{UniqueName: "user/old"})
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	_, err = tenantClient.Bucket().Create(ctx, &models.Bucket{Name: "old"})
+	if err != nil {
+		t.Fatalf("Failed to create bucket: %v", err)
+	}
+
+	return grid, gridClient
+}
+
+func parseSpec(t *testing.T, yaml string) *reconcile.Spec {
+	t.Helper()
+
+	spec, err := reconcile.ParseSpec([]byte(yaml))
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	return spec
+}
+
+// changes returns the changes of a plan in the form of Change.String
+func changes(plan *reconcile.Plan) []string {
+	changes := []string{}
+	for _, change := range plan.Changes {
+		changes = append(changes, change.String())
+	}
+
+	return changes
+}
+
+func TestPlan_Order(t *testing.T) {
+	grid, gridClient := newGrid(t)
+	r := reconcile.New(gridClient, reconcile.RootConnector(client.WithEndpoint(grid.URL)), reconcile.WithPrune(reconcile.PruneResources))
+
+	spec := parseSpec(t, `
+tenants:
+  - name: acme
+    rootPassword: `+rootPassword+`
+    groups:
+      - uniqueName: admins
+    users:
+      - uniqueName: alice
+        memberOf: [admins]
+    buckets:
+      - name: logs
+  - name: new
+    rootPassword: `+rootPassword+`
+    groups:
+      - uniqueName: admins
+    users:
+      - uniqueName: bob
+        memberOf: [admins]
+    buckets:
+      - name: data
+`)
+
+	ctx := context.Background()
+	plan, err := r.Plan(ctx, spec)
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+
+	// creates parents before children, then deletes children before parents
+	expected := []string{
+		"+ tenant new",
+		"+ group acme/group/admins",
+		"+ group new/group/admins",
+		"+ user acme/user/alice",
+		"+ user new/user/bob",
+		"+ bucket acme/logs",
+		"+ bucket new/data",
+		"- user acme/user/old",
+		"- group acme/group/old",
+		"- bucket acme/old",
+	}
+	if actual := changes(plan); !slices.Equal(actual, expected) {
+		t.Fatalf("Expected changes %v, got %v", expected, actual)
+	}
+
+	err = r.Apply(ctx, plan)
+	if err != nil {
+		t.Fatalf("Failed to apply:\n%s%v", plan, err)
+	}
+
+	plan, err = r.Plan(ctx, spec)
+	if err != nil {
+		t.Fatalf("Failed to plan: %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Expected no changes after apply, got:\n%s", plan)
+	}
+}
+
+func TestPlan_Prune(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     reconcile.PruneMode
+		expected []string
+	}{
+		{name: "none", mode: reconcile.PruneNone, expected: []string{}},
+		{name: "resources", mode: reconcile.PruneResources, expected: []string{"- user acme/user/old", "- group acme/group/old", "- bucket acme/old"}},
+		{name: "all", mode: reconcile.PruneAll, expected: []string{"- user acme/user/old", "- group acme/group/old", "- bucket acme/old", "- tenant other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, gridClient := newGrid(t)
+			r := reconcile.New(gridClient, reconcile.RootConnector(client.WithEndpoint(grid.URL)), reconcile.WithPrune(tt.mode))
+
+			// the root user is never pruned, although the spec does not list it
+			plan, err := r.Plan(context.Background(), parseSpec(t, `
+tenants:
+  - name: acme
+    rootPassword: `+rootPassword+`
+`))
+			if err != nil {
+				t.Fatalf("Failed to plan: %v", err)
+			}
+
+			if actual := changes(plan); !slices.Equal(actual, tt.expected) {
+				t.Errorf("Expected changes %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPlan_ImmutableBucket(t *testing.T) {
+	grid, gridClient := newGrid(t)
+	r := reconcile.New(gridClient, reconcile.RootConnector(client.WithEndpoint(grid.URL)))
+
+	plan, err := r.Plan(context.Background(), parseSpec(t, `
+tenants:
+  - name: acme
+    rootPassword: `+rootPassword+`
+    buckets:
+      - name: old
+        enableVersioning: true
+`))
+	if !errors.Is(err, reconcile.ErrImmutable) {
+		t.Fatalf("Expected ErrImmutable, got plan %v and error %v", plan, err)
+	}
+}
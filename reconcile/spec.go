@@ -0,0 +1,179 @@
+package reconcile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// Spec is the desired state of a grid. Fields left empty are not managed and keep their live values.
+type Spec struct {
+	Tenants []TenantSpec `json:"tenants"`
+}
+
+// TenantSpec is the desired state of a tenant account and the resources inside it
+type TenantSpec struct {
+	// Name identifies the account and must be unique on the grid
+	Name                     string   `json:"name"`
+	Description              *string  `json:"description,omitempty"`
+	Capabilities             []string `json:"capabilities,omitempty"`
+	QuotaObjectBytes         *int64   `json:"quotaObjectBytes,omitempty"`
+	AllowPlatformServices    *bool    `json:"allowPlatformServices,omitempty"`
+	AllowSelectObjectContent *bool    `json:"allowSelectObjectContent,omitempty"`
+	UseAccountIdentitySource *bool    `json:"useAccountIdentitySource,omitempty"`
+	// RootPassword is set when the account is created and passed to the Connector to sign in
+	RootPassword *string `json:"rootPassword,omitempty"`
+
+	Groups  []GroupSpec  `json:"groups,omitempty"`
+	Users   []UserSpec   `json:"users,omitempty"`
+	Buckets []BucketSpec `json:"buckets,omitempty"`
+}
+
+// GroupSpec is the desired state of a local tenant group
+type GroupSpec struct {
+	// UniqueName identifies the group, the "group/" prefix is optional
+	UniqueName         string                      `json:"uniqueName"`
+	DisplayName        *string                     `json:"displayName,omitempty"`
+	ManagementReadOnly *bool                       `json:"managementReadOnly,omitempty"`
+	Policies           *models.TenantGroupPolicies `json:"policies,omitempty"`
+}
+
+// UserSpec is the desired state of a local tenant user
+type UserSpec struct {
+	// UniqueName identifies the user, the "user/" prefix is optional
+	UniqueName string  `json:"uniqueName"`
+	FullName   *string `json:"fullName,omitempty"`
+	// MemberOf lists the unique names of the groups of the user, the "group/" prefix is optional
+	MemberOf []string `json:"memberOf,omitempty"`
+	Disable  *bool    `json:"disable,omitempty"`
+	// Password is set when the user is created
+	Password *string `json:"password,omitempty"`
+}
+
+// BucketSpec is the desired state of a bucket. Buckets cannot be changed after creation.
+type BucketSpec struct {
+	Name             string                             `json:"name"`
+	Region           *string                            `json:"region,omitempty"`
+	EnableVersioning *bool                              `json:"enableVersioning,omitempty"`
+	S3ObjectLock     *models.BucketS3ObjectLockSettings `json:"s3ObjectLock,omitempty"`
+}
+
+// LoadSpec reads a YAML or JSON spec from the file at path
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return spec, nil
+}
+
+// ParseSpec decodes a YAML or JSON spec. Field names are those of the JSON encoding, unknown fields are rejected.
+func ParseSpec(data []byte) (*Spec, error) {
+	var decoded interface{}
+	err := yaml.Unmarshal(data, &decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	data, err = json.Marshal(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	spec := &Spec{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// normalize returns a copy of the spec with prefixed unique names and checks that names are set and unique
+func (s *Spec) normalize() (*Spec, error) {
+	normalized := &Spec{Tenants: make([]TenantSpec, 0, len(s.Tenants))}
+	tenants := map[string]bool{}
+	for _, tenant := range s.Tenants {
+		if tenant.Name == "" {
+			return nil, fmt.Errorf("tenant name is required")
+		}
+		if tenants[tenant.Name] {
+			return nil, fmt.Errorf("tenant %s is defined more than once", tenant.Name)
+		}
+		tenants[tenant.Name] = true
+
+		groups := map[string]bool{}
+		tenant.Groups = append([]GroupSpec(nil), tenant.Groups...)
+		for i := range tenant.Groups {
+			group := &tenant.Groups[i]
+			if group.UniqueName == "" {
+				return nil, fmt.Errorf("tenant %s: group uniqueName is required", tenant.Name)
+			}
+			group.UniqueName = qualify(group.UniqueName, "group")
+			if groups[group.UniqueName] {
+				return nil, fmt.Errorf("tenant %s: group %s is defined more than once", tenant.Name, group.UniqueName)
+			}
+			groups[group.UniqueName] = true
+		}
+
+		users := map[string]bool{}
+		tenant.Users = append([]UserSpec(nil), tenant.Users...)
+		for i := range tenant.Users {
+			user := &tenant.Users[i]
+			if user.UniqueName == "" {
+				return nil, fmt.Errorf("tenant %s: user uniqueName is required", tenant.Name)
+			}
+			user.UniqueName = qualify(user.UniqueName, "user")
+			if users[user.UniqueName] {
+				return nil, fmt.Errorf("tenant %s: user %s is defined more than once", tenant.Name, user.UniqueName)
+			}
+			users[user.UniqueName] = true
+
+			if user.MemberOf != nil {
+				memberOf := make([]string, 0, len(user.MemberOf))
+				for _, group := range user.MemberOf {
+					memberOf = append(memberOf, qualify(group, "group"))
+				}
+				user.MemberOf = memberOf
+			}
+		}
+
+		buckets := map[string]bool{}
+		for _, bucket := range tenant.Buckets {
+			if bucket.Name == "" {
+				return nil, fmt.Errorf("tenant %s: bucket name is required", tenant.Name)
+			}
+			if buckets[bucket.Name] {
+				return nil, fmt.Errorf("tenant %s: bucket %s is defined more than once", tenant.Name, bucket.Name)
+			}
+			buckets[bucket.Name] = true
+		}
+
+		normalized.Tenants = append(normalized.Tenants, tenant)
+	}
+
+	return normalized, nil
+}
+
+// qualify adds the "<kind>/" prefix to names without a prefix
+func qualify(name string, kind string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+
+	return kind + "/" + name
+}
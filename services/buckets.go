@@ -31,6 +31,7 @@ func NewBucketService(client HTTPClient) *BucketService {
 	return &BucketService{client: client}
 }
 
+// List returns all buckets of the tenant. The endpoint is not paginated.
 func (s *BucketService) List(ctx context.Context) (*[]models.Bucket, error) {
 	response := models.Response{}
 	response.Data = &[]models.Bucket{}
@@ -93,7 +94,7 @@ func (s *BucketService) GetUsage(ctx context.Context, name string) (*models.Buck
 }
 
 func (s *BucketService) Delete(ctx context.Context, name string) error {
-	err := s.client.DoParsed(ctx, "DELETE", bucketEndpoint+"/"+name, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(bucketEndpoint, name), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -107,7 +108,7 @@ func (s *BucketService) Drain(ctx context.Context, name string) (*models.BucketD
 	response.Data = &models.BucketDeleteObjectStatus{}
 	body := map[string]string{"deleteObjects": "true"}
 
-	err := s.client.DoParsed(ctx, "POST", bucketEndpoint+"/"+name+"/delete-objects", body, &response)
+	err := s.client.DoParsed(ctx, "POST", resourcePath(bucketEndpoint, name, "delete-objects"), body, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +122,7 @@ func (s *BucketService) DrainStatus(ctx context.Context, name string) (*models.B
 	response := models.Response{}
 	response.Data = &models.BucketDeleteObjectStatus{}
 
-	err := s.client.DoParsed(ctx, "GET", bucketEndpoint+"/"+name+"/delete-objects", nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(bucketEndpoint, name, "delete-objects"), nil, &response)
 	if err != nil {
 		return nil, err
 	}
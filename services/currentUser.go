@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	gridCurrentUserEndpoint   string = "/grid/users/current-user"
+	tenantCurrentUserEndpoint string = "/org/users/current-user"
+)
+
+// CurrentUserServiceInterface defines the contract for operations on the signed-in user
+type CurrentUserServiceInterface interface {
+	Get(ctx context.Context) (*models.User, error)
+	GetPermissions(ctx context.Context) (*models.Permissions, error)
+	RequirePermissions(ctx context.Context, names ...string) error
+	ChangePassword(ctx context.Context, currentPassword string, newPassword string) error
+}
+
+type CurrentUserService struct {
+	client   HTTPClient
+	endpoint string
+}
+
+func NewCurrentUserGridService(client HTTPClient) *CurrentUserService {
+	return &CurrentUserService{client: client, endpoint: gridCurrentUserEndpoint}
+}
+
+func NewCurrentUserTenantService(client HTTPClient) *CurrentUserService {
+	return &CurrentUserService{client: client, endpoint: tenantCurrentUserEndpoint}
+}
+
+func (s *CurrentUserService) Get(ctx context.Context) (*models.User, error) {
+	response := models.Response{}
+	response.Data = &models.User{}
+	err := s.client.DoParsed(ctx, "GET", s.endpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	user := response.Data.(*models.User)
+
+	return user, nil
+}
+
+func (s *CurrentUserService) GetPermissions(ctx context.Context) (*models.Permissions, error) {
+	response := models.Response{}
+	response.Data = &models.Permissions{}
+	err := s.client.DoParsed(ctx, "GET", s.endpoint+"/permissions", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := response.Data.(*models.Permissions)
+
+	return permissions, nil
+}
+
+// RequirePermissions returns an error naming every permission the signed-in user lacks.
+func (s *CurrentUserService) RequirePermissions(ctx context.Context, names ...string) error {
+	permissions, err := s.GetPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		if !permissions.Has(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("current user lacks required permission(s): %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// ChangePassword changes the password of the signed-in user. Only local users can change their password.
+func (s *CurrentUserService) ChangePassword(ctx context.Context, currentPassword string, newPassword string) error {
+	data := map[string]string{"currentPassword": currentPassword, "password": newPassword}
+	err := s.client.DoParsed(ctx, "POST", s.endpoint+"/change-password", data, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
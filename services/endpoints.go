@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	endpointEndpoint string = "/org/endpoints"
+)
+
+// EndpointServiceInterface defines the contract for platform services endpoint operations
+type EndpointServiceInterface interface {
+	List(ctx context.Context) (*[]models.Endpoint, error)
+	GetById(ctx context.Context, id string) (*models.Endpoint, error)
+	Create(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	Update(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// EndpointService manages the platform services endpoints of a tenant account. The account needs the
+// AllowPlatformServices policy to use them.
+type EndpointService struct {
+	client HTTPClient
+}
+
+func NewEndpointService(client HTTPClient) *EndpointService {
+	return &EndpointService{client: client}
+}
+
+// List returns all endpoints of the tenant account. The endpoint is not paginated.
+func (s *EndpointService) List(ctx context.Context) (*[]models.Endpoint, error) {
+	response := models.Response{}
+	response.Data = &[]models.Endpoint{}
+	err := s.client.DoParsed(ctx, "GET", endpointEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := response.Data.(*[]models.Endpoint)
+
+	return endpoints, nil
+}
+
+func (s *EndpointService) GetById(ctx context.Context, id string) (*models.Endpoint, error) {
+	response := models.Response{}
+	response.Data = &models.Endpoint{}
+	err := s.client.DoParsed(ctx, "GET", resourcePath(endpointEndpoint, id), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := response.Data.(*models.Endpoint)
+
+	return endpoint, nil
+}
+
+// Create adds an endpoint. The grid tests the connection to the external service before it saves the endpoint.
+func (s *EndpointService) Create(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	response := models.Response{}
+	response.Data = &models.Endpoint{}
+	err := s.client.DoParsed(ctx, "POST", endpointEndpoint, endpoint, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint = response.Data.(*models.Endpoint)
+
+	return endpoint, nil
+}
+
+func (s *EndpointService) Update(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	response := models.Response{}
+	response.Data = &models.Endpoint{}
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(endpointEndpoint, endpoint.Id), endpoint, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint = response.Data.(*models.Endpoint)
+
+	return endpoint, nil
+}
+
+func (s *EndpointService) Delete(ctx context.Context, id string) error {
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(endpointEndpoint, id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
 )
@@ -24,7 +25,7 @@ type GatewayConfigServiceInterface interface {
 }
 
 func getServerConfigEndpoint(id string) string {
-	return fmt.Sprintf(serverConfigEndpoint, id)
+	return fmt.Sprintf(serverConfigEndpoint, url.PathEscape(id))
 }
 
 type GatewayConfigService struct {
@@ -35,6 +36,7 @@ func NewGatewayConfigService(client HTTPClient) *GatewayConfigService {
 	return &GatewayConfigService{client: client}
 }
 
+// ListGatewayConfigs returns all load balancer endpoints. The endpoint is not paginated.
 func (s *GatewayConfigService) ListGatewayConfigs(ctx context.Context) (*[]models.GatewayConfig, error) {
 	response := models.Response{}
 	response.Data = &[]models.GatewayConfig{}
@@ -51,7 +53,7 @@ func (s *GatewayConfigService) ListGatewayConfigs(ctx context.Context) (*[]model
 func (s *GatewayConfigService) GetGatewayConfigById(ctx context.Context, id string) (*models.GatewayConfig, error) {
 	response := models.Response{}
 	response.Data = &models.GatewayConfig{}
-	err := s.client.DoParsed(ctx, "GET", gatewayConfigEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(gatewayConfigEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +79,7 @@ func (s *GatewayConfigService) CreateGatewayConfig(ctx context.Context, gatewayC
 func (s *GatewayConfigService) UpdateGatewayConfig(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error) {
 	response := models.Response{}
 	response.Data = &models.GatewayConfig{}
-	err := s.client.DoParsed(ctx, "PUT", gatewayConfigEndpoint+"/"+gatewayConfig.Id, gatewayConfig, &response)
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(gatewayConfigEndpoint, gatewayConfig.Id), gatewayConfig, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +90,7 @@ func (s *GatewayConfigService) UpdateGatewayConfig(ctx context.Context, gatewayC
 }
 
 func (s *GatewayConfigService) DeleteGatewayConfig(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", gatewayConfigEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(gatewayConfigEndpoint, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	dnsServersEndpoint         string = "/grid/dns-servers"
+	ntpServersEndpoint         string = "/grid/ntp-servers"
+	gridNetworksEndpoint       string = "/grid/grid-networks"
+	gridNetworksUpdateEndpoint string = gridNetworksEndpoint + "/update"
+)
+
+// GridConfigServiceInterface defines the contract for grid network configuration service operations
+type GridConfigServiceInterface interface {
+	GetDNSServers(ctx context.Context) (*[]string, error)
+	UpdateDNSServers(ctx context.Context, servers []string) (*[]string, error)
+	GetNTPServers(ctx context.Context) (*[]string, error)
+	UpdateNTPServers(ctx context.Context, servers []string) (*[]string, error)
+	GetGridNetworks(ctx context.Context) (*[]string, error)
+	UpdateGridNetworks(ctx context.Context, subnets []string, passphrase string) (*[]string, error)
+}
+
+type GridConfigService struct {
+	client HTTPClient
+}
+
+func NewGridConfigService(client HTTPClient) *GridConfigService {
+	return &GridConfigService{client: client}
+}
+
+func (s *GridConfigService) GetDNSServers(ctx context.Context) (*[]string, error) {
+	return s.getList(ctx, dnsServersEndpoint)
+}
+
+// UpdateDNSServers replaces the external DNS servers. Every server must be an IP address.
+func (s *GridConfigService) UpdateDNSServers(ctx context.Context, servers []string) (*[]string, error) {
+	err := validateIPAddresses("DNS server", servers)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.putList(ctx, dnsServersEndpoint, servers)
+}
+
+func (s *GridConfigService) GetNTPServers(ctx context.Context) (*[]string, error) {
+	return s.getList(ctx, ntpServersEndpoint)
+}
+
+// UpdateNTPServers replaces the external NTP sources. Every source must be an IP address.
+func (s *GridConfigService) UpdateNTPServers(ctx context.Context, servers []string) (*[]string, error) {
+	err := validateIPAddresses("NTP server", servers)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.putList(ctx, ntpServersEndpoint, servers)
+}
+
+func (s *GridConfigService) GetGridNetworks(ctx context.Context) (*[]string, error) {
+	return s.getList(ctx, gridNetworksEndpoint)
+}
+
+// UpdateGridNetworks replaces the Grid Network subnet list. Every subnet must be a network address in CIDR notation.
+// Changing the Grid Network subnets requires the provisioning passphrase.
+func (s *GridConfigService) UpdateGridNetworks(ctx context.Context, subnets []string, passphrase string) (*[]string, error) {
+	if len(subnets) == 0 {
+		return nil, fmt.Errorf("at least one grid network subnet is required")
+	}
+
+	for _, subnet := range subnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grid network subnet %q: %w", subnet, err)
+		}
+		if prefix.Masked() != prefix {
+			return nil, fmt.Errorf("invalid grid network subnet %q: host bits set, did you mean %s", subnet, prefix.Masked())
+		}
+	}
+
+	body := &models.GridNetworksUpdate{Passphrase: passphrase, Subnets: subnets}
+
+	response := models.Response{}
+	response.Data = &[]string{}
+	err := s.client.DoParsed(ctx, "POST", gridNetworksUpdateEndpoint, body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	networks := response.Data.(*[]string)
+
+	return networks, nil
+}
+
+func (s *GridConfigService) getList(ctx context.Context, endpoint string) (*[]string, error) {
+	response := models.Response{}
+	response.Data = &[]string{}
+	err := s.client.DoParsed(ctx, "GET", endpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	list := response.Data.(*[]string)
+
+	return list, nil
+}
+
+func (s *GridConfigService) putList(ctx context.Context, endpoint string, list []string) (*[]string, error) {
+	response := models.Response{}
+	response.Data = &[]string{}
+	err := s.client.DoParsed(ctx, "PUT", endpoint, list, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := response.Data.(*[]string)
+
+	return updated, nil
+}
+
+func validateIPAddresses(kind string, addresses []string) error {
+	if len(addresses) == 0 {
+		return fmt.Errorf("at least one %s is required", kind)
+	}
+
+	for _, address := range addresses {
+		if _, err := netip.ParseAddr(address); err != nil {
+			return fmt.Errorf("invalid %s %q: must be an IP address", kind, address)
+		}
+	}
+
+	return nil
+}
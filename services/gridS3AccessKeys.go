@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	tenants3AccessKeyEndpoint string = tenantEndpoint + "/%s/s3-access-keys"
+)
+
+// GridS3AccessKeyServiceInterface defines the contract for S3 access key operations grid administrators perform on behalf of a tenant account's root user
+type GridS3AccessKeyServiceInterface interface {
+	ListForTenant(ctx context.Context, accountId string) (*[]models.S3AccessKey, error)
+	GetByIdForTenant(ctx context.Context, accountId string, id string) (*models.S3AccessKey, error)
+	CreateForTenant(ctx context.Context, accountId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error)
+	DeleteForTenant(ctx context.Context, accountId string, id string) error
+}
+
+func getTenants3AccessKeyEndpoint(accountId string) string {
+	return fmt.Sprintf(tenants3AccessKeyEndpoint, url.PathEscape(accountId))
+}
+
+type GridS3AccessKeyService struct {
+	client HTTPClient
+}
+
+func NewGridS3AccessKeyService(client HTTPClient) *GridS3AccessKeyService {
+	return &GridS3AccessKeyService{client: client}
+}
+
+func (s *GridS3AccessKeyService) ListForTenant(ctx context.Context, accountId string) (*[]models.S3AccessKey, error) {
+	response := models.Response{}
+	response.Data = &[]models.S3AccessKey{}
+	err := s.client.DoParsed(ctx, "GET", getTenants3AccessKeyEndpoint(accountId), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s3AccessKeys := response.Data.(*[]models.S3AccessKey)
+
+	return s3AccessKeys, nil
+}
+
+func (s *GridS3AccessKeyService) GetByIdForTenant(ctx context.Context, accountId string, id string) (*models.S3AccessKey, error) {
+	response := models.Response{}
+	response.Data = &models.S3AccessKey{}
+	err := s.client.DoParsed(ctx, "GET", resourcePath(getTenants3AccessKeyEndpoint(accountId), id), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s3AccessKey := response.Data.(*models.S3AccessKey)
+
+	return s3AccessKey, nil
+}
+
+// CreateForTenant creates a key pair for the root user of the tenant account. If AccessKey and SecretAccessKey are set, the given key pair is imported.
+func (s *GridS3AccessKeyService) CreateForTenant(ctx context.Context, accountId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	err := validateS3AccessKeyImport(s3AccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.Response{}
+	response.Data = &models.S3AccessKey{}
+	err = s.client.DoParsed(ctx, "POST", getTenants3AccessKeyEndpoint(accountId), s3AccessKey, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	s3AccessKey = response.Data.(*models.S3AccessKey)
+
+	return s3AccessKey, nil
+}
+
+func (s *GridS3AccessKeyService) DeleteForTenant(ctx context.Context, accountId string, id string) error {
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(getTenants3AccessKeyEndpoint(accountId), id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ func NewHAGroupService(client HTTPClient) *HAGroupService {
 	return &HAGroupService{client: client}
 }
 
+// List returns all HA groups. The endpoint is not paginated.
 func (s *HAGroupService) List(ctx context.Context) (*[]models.HAGroup, error) {
 	response := models.Response{}
 	response.Data = &[]models.HAGroup{}
@@ -43,7 +44,7 @@ func (s *HAGroupService) List(ctx context.Context) (*[]models.HAGroup, error) {
 func (s *HAGroupService) GetById(ctx context.Context, id string) (*models.HAGroup, error) {
 	response := models.Response{}
 	response.Data = &models.HAGroup{}
-	err := s.client.DoParsed(ctx, "GET", hagroupEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(hagroupEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +70,7 @@ func (s *HAGroupService) Create(ctx context.Context, hagroup *models.HAGroup) (*
 func (s *HAGroupService) Update(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error) {
 	response := models.Response{}
 	response.Data = &models.HAGroup{}
-	err := s.client.DoParsed(ctx, "PUT", hagroupEndpoint+"/"+hagroup.Id, hagroup, &response)
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(hagroupEndpoint, hagroup.Id), hagroup, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +81,7 @@ func (s *HAGroupService) Update(ctx context.Context, hagroup *models.HAGroup) (*
 }
 
 func (s *HAGroupService) Delete(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", hagroupEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(hagroupEndpoint, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	identitySourceEndpoint string = "/org/identity-source"
+)
+
+// identitySourceHint explains a failed creation of a federated user or group if the tenant has no enabled identity
+// source of its own. The creation is not validated up front, as accounts that use the grid identity source have none.
+func identitySourceHint(ctx context.Context, client HTTPClient, err error) error {
+	response := models.Response{}
+	response.Data = &models.IdentitySource{}
+	if client.DoParsed(ctx, "GET", identitySourceEndpoint, nil, &response) != nil {
+		return err
+	}
+
+	identitySource := response.Data.(*models.IdentitySource)
+	if identitySource.Enabled() {
+		return err
+	}
+
+	return fmt.Errorf("%w (the tenant has no enabled identity source, federated users and groups require one unless the account uses the grid identity source)", err)
+}
@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/client"
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/testing/fakegrid"
+)
+
+// newTenant starts a fake grid with a single tenant and returns a client signed in as its root user
+func newTenant(t *testing.T) (*fakegrid.Server, *client.TenantClient) {
+	t.Helper()
+
+	grid := fakegrid.New()
+	t.Cleanup(grid.Close)
+
+	tenant := grid.AddTenant("test", "password123")
+	credentials, err := grid.TenantCredentials(tenant.Id)
+	if err != nil {
+		t.Fatalf("Failed to get tenant credentials: %v", err)
+	}
+
+	tenantClient, err := client.NewTenantClient(
+		client.WithEndpoint(grid.URL),
+		client.WithCredentials(credentials),
+		client.WithSkipSSL(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create tenant client: %v", err)
+	}
+
+	return grid, tenantClient
+}
+
+// newUser creates a local user on the tenant and returns its ID
+func newUser(t *testing.T, tenantClient *client.TenantClient, uniqueName string) string {
+	t.Helper()
+
+	user, err := tenantClient.Users().Create(context.Background(), &models.User{UniqueName: uniqueName})
+	if err != nil {
+		t.Fatalf("Failed to create user %s: %v", uniqueName, err)
+	}
+
+	return *user.Id
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	nodeHealthEndpoint             string = "/grid/node-health"
+	untrustedClientNetworkEndpoint string = "/grid/untrusted-client-network"
+	firewallPrivilegedIpsEndpoint  string = "/grid/firewall-privileged-ips"
+	firewallExternalPortsEndpoint  string = "/grid/firewall-external-ports"
+)
+
+// NetworkSecurityServiceInterface defines the contract for untrusted client network and firewall service operations
+type NetworkSecurityServiceInterface interface {
+	ListNodes(ctx context.Context) (*[]models.Node, error)
+	GetUntrustedClientNetwork(ctx context.Context) (*models.UntrustedClientNetwork, error)
+	UpdateUntrustedClientNetwork(ctx context.Context, config *models.UntrustedClientNetwork) (*models.UntrustedClientNetwork, error)
+	SetNodeUntrusted(ctx context.Context, nodeId string, untrusted bool) (*models.UntrustedClientNetwork, error)
+	GetPrivilegedAddresses(ctx context.Context) (*models.FirewallPrivilegedAddresses, error)
+	UpdatePrivilegedAddresses(ctx context.Context, addresses *models.FirewallPrivilegedAddresses) (*models.FirewallPrivilegedAddresses, error)
+	GetExternalPorts(ctx context.Context) (*models.FirewallExternalPorts, error)
+	UpdateExternalPorts(ctx context.Context, ports *models.FirewallExternalPorts) (*models.FirewallExternalPorts, error)
+}
+
+type NetworkSecurityService struct {
+	client HTTPClient
+}
+
+func NewNetworkSecurityService(client HTTPClient) *NetworkSecurityService {
+	return &NetworkSecurityService{client: client}
+}
+
+func (s *NetworkSecurityService) ListNodes(ctx context.Context) (*[]models.Node, error) {
+	response := models.Response{}
+	response.Data = &[]models.Node{}
+	err := s.client.DoParsed(ctx, "GET", nodeHealthEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := response.Data.(*[]models.Node)
+
+	return nodes, nil
+}
+
+func (s *NetworkSecurityService) GetUntrustedClientNetwork(ctx context.Context) (*models.UntrustedClientNetwork, error) {
+	response := models.Response{}
+	response.Data = &models.UntrustedClientNetwork{}
+	err := s.client.DoParsed(ctx, "GET", untrustedClientNetworkEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	config := response.Data.(*models.UntrustedClientNetwork)
+
+	return config, nil
+}
+
+// UpdateUntrustedClientNetwork replaces the untrusted Client Network configuration.
+// Every node ID is checked against the grid topology before the update is sent.
+func (s *NetworkSecurityService) UpdateUntrustedClientNetwork(ctx context.Context, config *models.UntrustedClientNetwork) (*models.UntrustedClientNetwork, error) {
+	if config.NewNodeDefault != nil && *config.NewNodeDefault != models.ClientNetworkTrusted && *config.NewNodeDefault != models.ClientNetworkUntrusted {
+		return nil, fmt.Errorf("invalid new node default %q: must be %q or %q", *config.NewNodeDefault, models.ClientNetworkTrusted, models.ClientNetworkUntrusted)
+	}
+
+	err := s.validateNodeIds(ctx, config.Nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := models.Response{}
+	response.Data = &models.UntrustedClientNetwork{}
+	err = s.client.DoParsed(ctx, "PUT", untrustedClientNetworkEndpoint, config, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	config = response.Data.(*models.UntrustedClientNetwork)
+
+	return config, nil
+}
+
+// SetNodeUntrusted changes the Client Network trust setting of a single node and keeps all other nodes as they are.
+func (s *NetworkSecurityService) SetNodeUntrusted(ctx context.Context, nodeId string, untrusted bool) (*models.UntrustedClientNetwork, error) {
+	config, err := s.GetUntrustedClientNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for i := range config.Nodes {
+		if config.Nodes[i].NodeId == nodeId {
+			config.Nodes[i].Untrusted = untrusted
+			found = true
+		}
+	}
+
+	if !found {
+		config.Nodes = append(config.Nodes, models.UntrustedClientNetworkNode{NodeId: nodeId, Untrusted: untrusted})
+	}
+
+	return s.UpdateUntrustedClientNetwork(ctx, config)
+}
+
+func (s *NetworkSecurityService) GetPrivilegedAddresses(ctx context.Context) (*models.FirewallPrivilegedAddresses, error) {
+	response := models.Response{}
+	response.Data = &models.FirewallPrivilegedAddresses{}
+	err := s.client.DoParsed(ctx, "GET", firewallPrivilegedIpsEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := response.Data.(*models.FirewallPrivilegedAddresses)
+
+	return addresses, nil
+}
+
+// UpdatePrivilegedAddresses replaces the privileged address list. Every entry must be an IP address or a CIDR subnet.
+func (s *NetworkSecurityService) UpdatePrivilegedAddresses(ctx context.Context, addresses *models.FirewallPrivilegedAddresses) (*models.FirewallPrivilegedAddresses, error) {
+	for _, address := range addresses.PrivilegedIps {
+		if _, err := netip.ParsePrefix(address); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(address); err != nil {
+			return nil, fmt.Errorf("invalid privileged address %q: must be an IP address or CIDR subnet", address)
+		}
+	}
+
+	response := models.Response{}
+	response.Data = &models.FirewallPrivilegedAddresses{}
+	err := s.client.DoParsed(ctx, "PUT", firewallPrivilegedIpsEndpoint, addresses, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses = response.Data.(*models.FirewallPrivilegedAddresses)
+
+	return addresses, nil
+}
+
+func (s *NetworkSecurityService) GetExternalPorts(ctx context.Context) (*models.FirewallExternalPorts, error) {
+	response := models.Response{}
+	response.Data = &models.FirewallExternalPorts{}
+	err := s.client.DoParsed(ctx, "GET", firewallExternalPortsEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := response.Data.(*models.FirewallExternalPorts)
+
+	return ports, nil
+}
+
+// UpdateExternalPorts replaces the external port access control list.
+func (s *NetworkSecurityService) UpdateExternalPorts(ctx context.Context, ports *models.FirewallExternalPorts) (*models.FirewallExternalPorts, error) {
+	for _, port := range ports.Ports {
+		if port.Port < 1 || port.Port > 65535 {
+			return nil, fmt.Errorf("invalid port %d: must be between 1 and 65535", port.Port)
+		}
+		if port.Protocol != "tcp" && port.Protocol != "udp" {
+			return nil, fmt.Errorf("invalid protocol %q for port %d: must be tcp or udp", port.Protocol, port.Port)
+		}
+	}
+
+	response := models.Response{}
+	response.Data = &models.FirewallExternalPorts{}
+	err := s.client.DoParsed(ctx, "PUT", firewallExternalPortsEndpoint, ports, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	ports = response.Data.(*models.FirewallExternalPorts)
+
+	return ports, nil
+}
+
+// validateNodeIds ensures every node referenced by the configuration exists in the grid topology
+func (s *NetworkSecurityService) validateNodeIds(ctx context.Context, configNodes []models.UntrustedClientNetworkNode) error {
+	nodes, err := s.ListNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list grid nodes: %w", err)
+	}
+
+	known := make(map[string]bool, len(*nodes))
+	for _, node := range *nodes {
+		known[node.Id] = true
+	}
+
+	for _, node := range configNodes {
+		if !known[node.NodeId] {
+			return fmt.Errorf("node with id %s not found in grid topology", node.NodeId)
+		}
+	}
+
+	return nil
+}
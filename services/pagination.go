@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// OrderAsc sorts list results in ascending order
+	OrderAsc = "asc"
+	// OrderDesc sorts list results in descending order
+	OrderDesc = "desc"
+
+	defaultPageSize = 100
+)
+
+// ListOptions holds the paging and filtering parameters of list endpoints.
+type ListOptions struct {
+	// Limit is the maximum number of items per page. Zero lets the server decide.
+	Limit int
+	// Marker is the ID of the item after which the page starts.
+	Marker string
+	// IncludeMarker includes the item referenced by Marker in the page.
+	IncludeMarker bool
+	// Order is the sort order, either OrderAsc or OrderDesc.
+	Order string
+	// Filters contains additional query parameters, e.g. "type" to select local or federated users.
+	Filters url.Values
+}
+
+// ListOption configures a list request.
+type ListOption func(*ListOptions)
+
+func WithLimit(limit int) ListOption {
+	return func(o *ListOptions) {
+		o.Limit = limit
+	}
+}
+
+func WithMarker(marker string) ListOption {
+	return func(o *ListOptions) {
+		o.Marker = marker
+	}
+}
+
+func WithIncludeMarker() ListOption {
+	return func(o *ListOptions) {
+		o.IncludeMarker = true
+	}
+}
+
+func WithOrder(order string) ListOption {
+	return func(o *ListOptions) {
+		o.Order = order
+	}
+}
+
+// WithFilter adds a filter query parameter, e.g. WithFilter("type", "federated").
+func WithFilter(name string, value string) ListOption {
+	return func(o *ListOptions) {
+		if o.Filters == nil {
+			o.Filters = url.Values{}
+		}
+		o.Filters.Add(name, value)
+	}
+}
+
+func newListOptions(opts ...ListOption) *ListOptions {
+	options := &ListOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// Query returns the options as URL query values.
+func (o *ListOptions) Query() url.Values {
+	query := url.Values{}
+	for name, values := range o.Filters {
+		for _, value := range values {
+			query.Add(name, value)
+		}
+	}
+
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Marker != "" {
+		query.Set("marker", o.Marker)
+		if o.IncludeMarker {
+			query.Set("includeMarker", "true")
+		}
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+
+	return query
+}
+
+// ListIter iterates over all items of a paginated list endpoint, following markers transparently.
+//
+//	it := gridClient.Tenant().Iter(services.WithLimit(500))
+//	for it.Next(ctx) {
+//		tenant := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ListIter[T any] struct {
+	fetch   func(ctx context.Context, opts ...ListOption) (*[]T, error)
+	key     func(T) string
+	options ListOptions
+
+	page    []T
+	pos     int
+	current T
+	started bool
+	done    bool
+	err     error
+}
+
+// NewListIter returns an iterator calling fetch page by page. key returns the marker of an item, usually its ID.
+// The page size defaults to 100 if no limit is set.
+func NewListIter[T any](fetch func(ctx context.Context, opts ...ListOption) (*[]T, error), key func(T) string, opts ...ListOption) *ListIter[T] {
+	options := newListOptions(opts...)
+	if options.Limit <= 0 {
+		options.Limit = defaultPageSize
+	}
+
+	return &ListIter[T]{fetch: fetch, key: key, options: *options}
+}
+
+// Next advances to the next item, fetching the next page if necessary. It returns false when all items
+// were read or an error occurred.
+func (it *ListIter[T]) Next(ctx context.Context) bool {
+	for it.pos >= len(it.page) {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.nextPage(ctx)
+	}
+
+	it.current = it.page[it.pos]
+	it.pos++
+
+	return true
+}
+
+// Value returns the current item.
+func (it *ListIter[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ListIter[T]) Err() error {
+	return it.err
+}
+
+// All reads the remaining items into a slice.
+func (it *ListIter[T]) All(ctx context.Context) (*[]T, error) {
+	items := []T{}
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	return &items, nil
+}
+
+// Seq returns the remaining items as a range-over-func sequence. Iteration stops after the first error.
+func (it *ListIter[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+func (it *ListIter[T]) nextPage(ctx context.Context) {
+	options := it.options
+	if it.started {
+		options.IncludeMarker = false
+	}
+	it.started = true
+
+	page, err := it.fetch(ctx, func(o *ListOptions) { *o = options })
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = *page
+	it.pos = 0
+
+	if len(it.page) < options.Limit || len(it.page) == 0 {
+		it.done = true
+		return
+	}
+
+	// an empty marker would restart the list and a repeated one fetch the same page, both forever
+	marker := it.key(it.page[len(it.page)-1])
+	switch {
+	case marker == "":
+		it.err = fmt.Errorf("cannot fetch the next page: the last item has no marker")
+		return
+	case marker == options.Marker && !options.IncludeMarker:
+		it.err = fmt.Errorf("cannot fetch the next page: marker %q did not advance", marker)
+		return
+	}
+	it.options.Marker = marker
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// pagedList serves items by marker like the list endpoints of the grid and records the options of every request
+type pagedList struct {
+	items    []string
+	requests []ListOptions
+	err      error
+}
+
+func (l *pagedList) fetch(ctx context.Context, opts ...ListOption) (*[]string, error) {
+	options := newListOptions(opts...)
+	l.requests = append(l.requests, *options)
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	start := 0
+	if options.Marker != "" {
+		start = slices.Index(l.items, options.Marker)
+		if !options.IncludeMarker {
+			start++
+		}
+	}
+	end := min(start+options.Limit, len(l.items))
+	page := slices.Clone(l.items[start:end])
+
+	return &page, nil
+}
+
+func identity(item string) string {
+	return item
+}
+
+func numbered(n int) []string {
+	items := make([]string, 0, n)
+	for i := range n {
+		items = append(items, strconv.Itoa(i))
+	}
+	return items
+}
+
+func TestListIter(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []string
+		opts         []ListOption
+		wantItems    []string
+		wantRequests int
+	}{
+		{"empty list", nil, nil, []string{}, 1},
+		{"single page", numbered(3), []ListOption{WithLimit(5)}, numbered(3), 1},
+		{"full last page needs another request", numbered(4), []ListOption{WithLimit(2)}, numbered(4), 3},
+		{"partial last page", numbered(5), []ListOption{WithLimit(2)}, numbered(5), 3},
+		{"default page size", numbered(150), nil, numbered(150), 2},
+		{"start after marker", numbered(5), []ListOption{WithLimit(2), WithMarker("1")}, numbered(5)[2:], 2},
+		{"start at marker", numbered(5), []ListOption{WithLimit(2), WithMarker("1"), WithIncludeMarker()}, numbered(5)[1:], 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &pagedList{items: tt.items}
+			items, err := NewListIter(list.fetch, identity, tt.opts...).All(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to list: %v", err)
+			}
+
+			if !slices.Equal(*items, tt.wantItems) {
+				t.Errorf("Expected items %v, got %v", tt.wantItems, *items)
+			}
+			if len(list.requests) != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, len(list.requests))
+			}
+			for i, request := range list.requests[1:] {
+				if request.IncludeMarker {
+					t.Errorf("Expected request %d to exclude the marker", i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestListIter_Errors(t *testing.T) {
+	failure := errors.New("list failed")
+
+	tests := []struct {
+		name  string
+		items []string
+		key   func(string) string
+		err   error
+	}{
+		{"fetch error", numbered(3), identity, failure},
+		{"item without marker", numbered(3), func(string) string { return "" }, nil},
+		{"marker does not advance", numbered(3), func(string) string { return "0" }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &pagedList{items: tt.items, err: tt.err}
+			it := NewListIter(list.fetch, tt.key, WithLimit(1))
+
+			items, err := it.All(context.Background())
+			if err == nil || items != nil {
+				t.Fatalf("Expected an error, got %v", items)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+			if len(list.requests) > 2 {
+				t.Errorf("Expected the iteration to stop, got %d requests", len(list.requests))
+			}
+		})
+	}
+}
+
+func TestListIter_Seq(t *testing.T) {
+	list := &pagedList{items: numbered(10)}
+	it := NewListIter(list.fetch, identity, WithLimit(2))
+
+	read := []string{}
+	for item, err := range it.Seq(context.Background()) {
+		if err != nil {
+			t.Fatalf("Failed to list: %v", err)
+		}
+		read = append(read, item)
+		if len(read) == 3 {
+			break
+		}
+	}
+
+	if !slices.Equal(read, numbered(3)) || len(list.requests) != 2 {
+		t.Errorf("Expected 3 items from 2 requests, got %v from %d", read, len(list.requests))
+	}
+
+	failing := &pagedList{err: errors.New("list failed")}
+	for _, err := range NewListIter(failing.fetch, identity).Seq(context.Background()) {
+		if err == nil {
+			t.Fatalf("Expected the error to be yielded")
+		}
+	}
+}
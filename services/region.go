@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
 )
@@ -16,13 +19,31 @@ type RegionServiceInterface interface {
 	List(ctx context.Context) (*[]string, error)
 }
 
+// GridRegionServiceInterface defines the contract for region service operations available to grid administrators
+type GridRegionServiceInterface interface {
+	RegionServiceInterface
+	ListDetailed(ctx context.Context) (*[]models.Region, error)
+	Set(ctx context.Context, regions []string) ([]string, error)
+	Create(ctx context.Context, name string) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
 type RegionService struct {
 	client   HTTPClient
 	endpoint string
 }
 
-func NewRegionGridService(client HTTPClient) *RegionService {
-	return &RegionService{client: client, endpoint: gridRegionEndpoint}
+// GridRegionService extends RegionService with region management. Bucket references are resolved through tenant usage.
+type GridRegionService struct {
+	*RegionService
+	tenants TenantServiceInterface
+}
+
+func NewRegionGridService(client HTTPClient) *GridRegionService {
+	return &GridRegionService{
+		RegionService: &RegionService{client: client, endpoint: gridRegionEndpoint},
+		tenants:       NewTenantService(client),
+	}
 }
 
 func NewRegionTenantService(client HTTPClient) *RegionService {
@@ -41,3 +62,148 @@ func (s *RegionService) List(ctx context.Context) (*[]string, error) {
 
 	return regions, nil
 }
+
+// ListDetailed returns all regions together with the buckets referencing them.
+// This reads the usage of every tenant account and can be slow on grids with many tenants.
+func (s *GridRegionService) ListDetailed(ctx context.Context) (*[]models.Region, error) {
+	names, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]models.Region, 0, len(*names))
+	index := make(map[string]int, len(*names))
+	for i, name := range *names {
+		regions = append(regions, models.Region{Name: name})
+		index[name] = i
+	}
+
+	tenants, err := s.tenants.Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	for _, tenant := range *tenants {
+		usage, err := s.tenants.GetUsage(ctx, tenant.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get usage of tenant %s: %w", tenant.Id, err)
+		}
+
+		for _, bucket := range usage.Buckets {
+			if bucket == nil || bucket.Name == nil {
+				continue
+			}
+
+			region := models.DefaultRegion
+			if bucket.Region != nil && *bucket.Region != "" {
+				region = *bucket.Region
+			}
+
+			i, ok := index[region]
+			if !ok {
+				// buckets may reference regions that are not (or no longer) configured, report them anyway
+				regions = append(regions, models.Region{Name: region})
+				i = len(regions) - 1
+				index[region] = i
+			}
+
+			regions[i].Buckets = append(regions[i].Buckets, models.RegionBucket{AccountId: tenant.Id, Name: *bucket.Name})
+		}
+	}
+
+	return &regions, nil
+}
+
+// Set replaces the full list of regions and returns the regions now configured.
+// Removing a region that is still referenced by a bucket is refused.
+func (s *GridRegionService) Set(ctx context.Context, regions []string) ([]string, error) {
+	if !slices.Contains(regions, models.DefaultRegion) {
+		return nil, fmt.Errorf("the default region %s cannot be removed", models.DefaultRegion)
+	}
+
+	current, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, region := range *current {
+		if !slices.Contains(regions, region) {
+			removed = append(removed, region)
+		}
+	}
+
+	if len(removed) > 0 {
+		err = s.ensureUnused(ctx, removed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := models.Response{}
+	response.Data = &[]string{}
+	err = s.client.DoParsed(ctx, "PUT", s.endpoint, regions, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := response.Data.(*[]string)
+
+	return *updated, nil
+}
+
+// Create adds a region to the list of regions and returns the regions now configured. Adding an existing region is a no-op.
+func (s *GridRegionService) Create(ctx context.Context, name string) ([]string, error) {
+	regions, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(*regions, name) {
+		return *regions, nil
+	}
+
+	return s.Set(ctx, append(*regions, name))
+}
+
+// Delete removes a region from the list of regions. Deleting a region that is still referenced by a bucket is refused.
+func (s *GridRegionService) Delete(ctx context.Context, name string) error {
+	regions, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(*regions, name) {
+		return fmt.Errorf("region with name %s not found", name)
+	}
+
+	_, err = s.Set(ctx, slices.DeleteFunc(*regions, func(region string) bool { return region == name }))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureUnused returns an error listing every bucket that references one of the given regions
+func (s *GridRegionService) ensureUnused(ctx context.Context, names []string) error {
+	regions, err := s.ListDetailed(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, region := range *regions {
+		if !slices.Contains(names, region.Name) || !region.InUse() {
+			continue
+		}
+
+		buckets := make([]string, 0, len(region.Buckets))
+		for _, bucket := range region.Buckets {
+			buckets = append(buckets, bucket.AccountId+"/"+bucket.Name)
+		}
+
+		return fmt.Errorf("cannot remove region %s: still referenced by bucket(s) %s", region.Name, strings.Join(buckets, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// RequestOptions holds the optional parts of a request sent through HTTPClient.
+type RequestOptions struct {
+	// Query is appended to the request URL.
+	Query url.Values
+	// Header is added to the request headers.
+	Header http.Header
+	// RawBody is sent as is instead of the JSON encoded body argument.
+	RawBody []byte
+	// ContentType is the content type of RawBody.
+	ContentType string
+	// ExpectedStatus lists the status codes treated as success. Empty means any 2xx code.
+	ExpectedStatus []int
+}
+
+// RequestOption configures a request sent through HTTPClient.
+type RequestOption func(*RequestOptions)
+
+func WithQuery(query url.Values) RequestOption {
+	return func(o *RequestOptions) {
+		if o.Query == nil {
+			o.Query = url.Values{}
+		}
+		for name, values := range query {
+			for _, value := range values {
+				o.Query.Add(name, value)
+			}
+		}
+	}
+}
+
+func WithHeader(name string, value string) RequestOption {
+	return func(o *RequestOptions) {
+		if o.Header == nil {
+			o.Header = http.Header{}
+		}
+		o.Header.Add(name, value)
+	}
+}
+
+func WithRawBody(body []byte, contentType string) RequestOption {
+	return func(o *RequestOptions) {
+		o.RawBody = body
+		o.ContentType = contentType
+	}
+}
+
+func WithExpectedStatus(codes ...int) RequestOption {
+	return func(o *RequestOptions) {
+		o.ExpectedStatus = append(o.ExpectedStatus, codes...)
+	}
+}
+
+// NewRequestOptions applies opts to an empty RequestOptions.
+func NewRequestOptions(opts ...RequestOption) *RequestOptions {
+	options := &RequestOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// IsExpectedStatus reports whether code counts as a successful response.
+func (o *RequestOptions) IsExpectedStatus(code int) bool {
+	if len(o.ExpectedStatus) == 0 {
+		return code >= 200 && code < 300
+	}
+
+	return slices.Contains(o.ExpectedStatus, code)
+}
+
+// resourcePath joins endpoint and the path escaped segments
+func resourcePath(endpoint string, segments ...string) string {
+	var path strings.Builder
+	path.WriteString(endpoint)
+	for _, segment := range segments {
+		path.WriteString("/")
+		path.WriteString(url.PathEscape(segment))
+	}
+
+	return path.String()
+}
+
+// uniqueNamePath returns the path of a user or group addressed by its unique name, e.g. "group/admins".
+// The type prefix stays a path segment, the name itself is escaped.
+func uniqueNamePath(endpoint string, uniqueName string) string {
+	prefix, name, found := strings.Cut(uniqueName, "/")
+	if !found {
+		return resourcePath(endpoint, uniqueName)
+	}
+
+	return resourcePath(endpoint, prefix, name)
+}
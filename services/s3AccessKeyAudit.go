@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	defaultAuditExpiringWithin = 30 * 24 * time.Hour
+	defaultAuditConcurrency    = 4
+)
+
+// S3AccessKeyStatus describes the expiry status of an S3 access key.
+type S3AccessKeyStatus string
+
+const (
+	S3AccessKeyExpired      S3AccessKeyStatus = "expired"
+	S3AccessKeyExpiringSoon S3AccessKeyStatus = "expiring-soon"
+	S3AccessKeyValid        S3AccessKeyStatus = "valid"
+	S3AccessKeyNeverExpires S3AccessKeyStatus = "never"
+)
+
+// S3AccessKeyAuditOptions configures AuditS3AccessKeys.
+type S3AccessKeyAuditOptions struct {
+	// ExpiringWithin is the window in which a key counts as expiring soon. Defaults to 30 days.
+	ExpiringWithin time.Duration
+	// Concurrency is the maximum number of users whose keys are read in parallel. Defaults to 4.
+	Concurrency int
+	// DeleteExpired deletes every expired key found during the audit.
+	DeleteExpired bool
+}
+
+// S3AccessKeyAuditEntry is a single key found during an audit.
+type S3AccessKeyAuditEntry struct {
+	User   models.User
+	Key    models.S3AccessKey
+	Status S3AccessKeyStatus
+	// Deleted is true if the key was deleted because DeleteExpired was set.
+	Deleted bool
+}
+
+// S3AccessKeyAuditReport is the result of AuditS3AccessKeys.
+type S3AccessKeyAuditReport struct {
+	// Entries contains every key of every user, ordered by user.
+	Entries []S3AccessKeyAuditEntry
+	// DisabledUsersWithKeys contains disabled users that still hold at least one key.
+	DisabledUsersWithKeys []models.User
+}
+
+// Count returns the number of keys with the given status.
+func (r *S3AccessKeyAuditReport) Count(status S3AccessKeyStatus) int {
+	count := 0
+	for _, entry := range r.Entries {
+		if entry.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AuditS3AccessKeys lists the S3 access keys of every user of a tenant and classifies them by expiry.
+// The number of parallel requests is limited by S3AccessKeyAuditOptions.Concurrency.
+// The first error stops the audit. It is returned together with the keys read so far, including deleted keys.
+func AuditS3AccessKeys(ctx context.Context, users TenantUserServiceInterface, keys S3AccessKeyServiceInterface, opts *S3AccessKeyAuditOptions) (*S3AccessKeyAuditReport, error) {
+	options := S3AccessKeyAuditOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.ExpiringWithin <= 0 {
+		options.ExpiringWithin = defaultAuditExpiringWithin
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultAuditConcurrency
+	}
+
+	userList, err := users.Iter().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	now := time.Now()
+	results := make([][]S3AccessKeyAuditEntry, len(*userList))
+	sem := make(chan struct{}, options.Concurrency)
+	wg := sync.WaitGroup{}
+
+	// only the first error is kept, as it cancels the other workers which then fail with context.Canceled
+	var firstErr error
+	once := sync.Once{}
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i, user := range *userList {
+		if user.Id == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+
+			var err error
+			results[i], err = auditUser(ctx, keys, user, now, &options)
+			if err != nil {
+				fail(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	report := &S3AccessKeyAuditReport{Entries: []S3AccessKeyAuditEntry{}}
+	for i, user := range *userList {
+		report.Entries = append(report.Entries, results[i]...)
+
+		if len(results[i]) > 0 && user.Disable != nil && *user.Disable {
+			report.DisabledUsersWithKeys = append(report.DisabledUsersWithKeys, user)
+		}
+	}
+
+	return report, firstErr
+}
+
+// auditUser returns the entries of the keys handled so far together with an error
+func auditUser(ctx context.Context, keys S3AccessKeyServiceInterface, user models.User, now time.Time, opts *S3AccessKeyAuditOptions) ([]S3AccessKeyAuditEntry, error) {
+	userKeys, err := keys.ListForUser(ctx, *user.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list s3 access keys of user %s: %w", user.UniqueName, err)
+	}
+
+	entries := make([]S3AccessKeyAuditEntry, 0, len(*userKeys))
+	for _, key := range *userKeys {
+		entry := S3AccessKeyAuditEntry{User: user, Key: key, Status: keyStatus(key, now, opts.ExpiringWithin)}
+
+		if opts.DeleteExpired && entry.Status == S3AccessKeyExpired && key.Id != nil {
+			err = keys.DeleteForUser(ctx, *user.Id, *key.Id)
+			if err != nil {
+				return entries, fmt.Errorf("failed to delete expired s3 access key %s of user %s: %w", *key.Id, user.UniqueName, err)
+			}
+			entry.Deleted = true
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func keyStatus(key models.S3AccessKey, now time.Time, expiringWithin time.Duration) S3AccessKeyStatus {
+	switch {
+	case key.Expires == nil:
+		return S3AccessKeyNeverExpires
+	case !key.Expires.After(now):
+		return S3AccessKeyExpired
+	case key.Expires.Before(now.Add(expiringWithin)):
+		return S3AccessKeyExpiringSoon
+	default:
+		return S3AccessKeyValid
+	}
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+// failingKeys reports the keys of the audited user as expired and fails to list the keys of another user once
+// they were deleted. Every other user is held until the audit is cancelled.
+type failingKeys struct {
+	services.S3AccessKeyServiceInterface
+	failUser    string
+	auditedUser string
+	audited     chan struct{}
+	err         error
+}
+
+func (k *failingKeys) ListForUser(ctx context.Context, userId string) (*[]models.S3AccessKey, error) {
+	switch userId {
+	case k.auditedUser:
+		keys, err := k.S3AccessKeyServiceInterface.ListForUser(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		expired := time.Now().Add(-time.Hour)
+		for i := range *keys {
+			(*keys)[i].Expires = &expired
+		}
+		return keys, nil
+	case k.failUser:
+		<-k.audited
+		return nil, k.err
+	default:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+}
+
+func (k *failingKeys) DeleteForUser(ctx context.Context, userId string, keyId string) error {
+	err := k.S3AccessKeyServiceInterface.DeleteForUser(ctx, userId, keyId)
+	close(k.audited)
+	return err
+}
+
+func TestAuditS3AccessKeys(t *testing.T) {
+	ctx := context.Background()
+	_, tenantClient := newTenant(t)
+	keys := tenantClient.S3AccessKeys()
+
+	expiring := time.Now().UTC().Add(7 * 24 * time.Hour)
+	valid := time.Now().UTC().Add(90 * 24 * time.Hour)
+	userId := newUser(t, tenantClient, "user/audit")
+	for _, key := range []*models.S3AccessKey{{Expires: &expiring}, {Expires: &valid}, {}} {
+		_, err := keys.CreateForUser(ctx, userId, key)
+		if err != nil {
+			t.Fatalf("Failed to create key: %v", err)
+		}
+	}
+
+	report, err := tenantClient.AuditS3AccessKeys(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to audit: %v", err)
+	}
+
+	for status, want := range map[services.S3AccessKeyStatus]int{
+		services.S3AccessKeyExpiringSoon: 1,
+		services.S3AccessKeyValid:        1,
+		services.S3AccessKeyNeverExpires: 1,
+		services.S3AccessKeyExpired:      0,
+	} {
+		if got := report.Count(status); got != want {
+			t.Errorf("Expected %d %s keys, got %d", want, status, got)
+		}
+	}
+}
+
+func TestAuditS3AccessKeys_FirstError(t *testing.T) {
+	ctx := context.Background()
+	_, tenantClient := newTenant(t)
+
+	// the waiting users are listed before the failing one, so their cancellation must not hide its error
+	for _, name := range []string{"user/a", "user/b"} {
+		newUser(t, tenantClient, name)
+	}
+	auditedUser := newUser(t, tenantClient, "user/audited")
+	failUser := newUser(t, tenantClient, "user/failing")
+
+	key, err := tenantClient.S3AccessKeys().CreateForUser(ctx, auditedUser, &models.S3AccessKey{})
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+
+	failure := errors.New("list failed")
+	keys := &failingKeys{
+		S3AccessKeyServiceInterface: tenantClient.S3AccessKeys(),
+		failUser:                    failUser,
+		auditedUser:                 auditedUser,
+		audited:                     make(chan struct{}),
+		err:                         failure,
+	}
+
+	report, err := services.AuditS3AccessKeys(ctx, tenantClient.Users(), keys, &services.S3AccessKeyAuditOptions{Concurrency: 8, DeleteExpired: true})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected the failure of the listing, got %v", err)
+	}
+	if report == nil || len(report.Entries) != 1 || !report.Entries[0].Deleted || *report.Entries[0].Key.Id != *key.Id {
+		t.Fatalf("Expected the partial report to contain the deleted key, got %+v", report)
+	}
+}
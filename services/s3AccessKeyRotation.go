@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// S3AccessKeyRotateOptions configures S3AccessKeyService.RotateForUser.
+//
+// StorageGRID does not report when a key was created. The age of a key is therefore derived from its expiry date
+// minus Lifetime, which holds for every key created by RotateForUser with the same Lifetime. Keys without an expiry
+// date, and all keys if Lifetime is zero, have an unknown age and are treated as the oldest keys.
+//
+// The grace period of a key starts when it was superseded, i.e. when the next newer key was created. Keys replaced by
+// the key of the current rotation therefore stay valid for the full GracePeriod.
+type S3AccessKeyRotateOptions struct {
+	// Lifetime is the validity of the new key. The new key never expires if Lifetime is zero.
+	Lifetime time.Duration
+	// GracePeriod is the overlap window during which older keys stay valid after a rotation.
+	GracePeriod time.Duration
+	// DeleteOld deletes older keys whose grace period has elapsed. Keys still within the grace period, and keys with an
+	// unknown age, are never deleted by DeleteOld and are returned as pending.
+	DeleteOld bool
+	// KeepMax is the maximum number of keys the user keeps, including the new key. The oldest keys are deleted first.
+	// Zero disables pruning.
+	KeepMax int
+}
+
+// S3AccessKeyRotation is the result of S3AccessKeyService.RotateForUser.
+type S3AccessKeyRotation struct {
+	// Key is the new key pair, including the secret access key.
+	Key *models.S3AccessKey
+	// Deleted contains the keys deleted during the rotation.
+	Deleted []models.S3AccessKey
+	// Pending contains older keys that are still within the grace period or have an unknown age and should be deleted later.
+	Pending []S3AccessKeyPendingDeletion
+}
+
+// S3AccessKeyPendingDeletion is an older key together with the time its grace period ends.
+type S3AccessKeyPendingDeletion struct {
+	Key         models.S3AccessKey
+	DeleteAfter time.Time
+}
+
+// RotateForUser creates a new key pair for the user and optionally deletes older keys.
+// The new key is never deleted, even if KeepMax is smaller than one.
+func (s *S3AccessKeyService) RotateForUser(ctx context.Context, userId string, opts *S3AccessKeyRotateOptions) (*S3AccessKeyRotation, error) {
+	if opts == nil {
+		opts = &S3AccessKeyRotateOptions{}
+	}
+
+	if opts.Lifetime < 0 || opts.GracePeriod < 0 || opts.KeepMax < 0 {
+		return nil, fmt.Errorf("rotate options must not be negative")
+	}
+
+	now := time.Now().UTC()
+
+	newKey := &models.S3AccessKey{}
+	if opts.Lifetime > 0 {
+		expires := now.Add(opts.Lifetime)
+		newKey.Expires = &expires
+	}
+
+	created, err := s.CreateForUser(ctx, userId, newKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 access key: %w", err)
+	}
+
+	rotation := &S3AccessKeyRotation{Key: created}
+
+	keys, err := s.ListForUser(ctx, userId)
+	if err != nil {
+		return rotation, fmt.Errorf("failed to list s3 access keys: %w", err)
+	}
+
+	older := []models.S3AccessKey{}
+	for _, key := range *keys {
+		if key.Id != nil && created.Id != nil && *key.Id == *created.Id {
+			continue
+		}
+		older = append(older, key)
+	}
+
+	// oldest keys first, keys without expiry are considered the oldest
+	slices.SortStableFunc(older, func(a, b models.S3AccessKey) int {
+		switch {
+		case a.Expires == nil && b.Expires == nil:
+			return 0
+		case a.Expires == nil:
+			return -1
+		case b.Expires == nil:
+			return 1
+		default:
+			return a.Expires.Compare(*b.Expires)
+		}
+	})
+
+	excess := 0
+	if opts.KeepMax > 0 {
+		excess = len(older) + 1 - opts.KeepMax
+	}
+
+	for i, key := range older {
+		deleteAfter, known := graceEnd(older, i, opts, now)
+
+		if i < excess || (opts.DeleteOld && known && !now.Before(deleteAfter)) {
+			if key.Id == nil {
+				continue
+			}
+
+			err = s.DeleteForUser(ctx, userId, *key.Id)
+			if err != nil {
+				return rotation, fmt.Errorf("failed to delete s3 access key %s: %w", *key.Id, err)
+			}
+
+			rotation.Deleted = append(rotation.Deleted, key)
+			continue
+		}
+
+		rotation.Pending = append(rotation.Pending, S3AccessKeyPendingDeletion{Key: key, DeleteAfter: deleteAfter})
+	}
+
+	return rotation, nil
+}
+
+// graceEnd returns the time the grace period of older[i] ends, counted from the creation of the next newer key, or
+// from now if that is the key of the current rotation or its creation is unknown. It reports false if the age of
+// older[i] itself is unknown, such keys end their grace period at the earliest now plus GracePeriod.
+func graceEnd(older []models.S3AccessKey, i int, opts *S3AccessKeyRotateOptions, now time.Time) (time.Time, bool) {
+	_, known := estimateCreation(older[i], opts)
+	if !known {
+		return now.Add(opts.GracePeriod), false
+	}
+
+	supersededAt := now
+	if i+1 < len(older) {
+		created, ok := estimateCreation(older[i+1], opts)
+		if ok && created.Before(now) {
+			supersededAt = created
+		}
+	}
+
+	return supersededAt.Add(opts.GracePeriod), true
+}
+
+// estimateCreation returns the creation time of the key. It reports false if the age of the key is unknown.
+func estimateCreation(key models.S3AccessKey, opts *S3AccessKeyRotateOptions) (time.Time, bool) {
+	if key.Expires == nil || opts.Lifetime == 0 {
+		return time.Time{}, false
+	}
+
+	return key.Expires.Add(-opts.Lifetime), true
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+func TestRotateForUser_GracePeriod(t *testing.T) {
+	const lifetime = 24 * time.Hour
+	const grace = time.Hour
+
+	// age of a key created by an earlier rotation with the same lifetime, negative ages have no expiry
+	const noExpiry = -1
+
+	tests := []struct {
+		name        string
+		ages        []time.Duration
+		opts        services.S3AccessKeyRotateOptions
+		wantDeleted int
+		wantPending int
+	}{
+		{
+			name:        "key replaced now keeps the full grace period",
+			ages:        []time.Duration{5 * time.Hour},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, GracePeriod: grace, DeleteOld: true},
+			wantPending: 1,
+		},
+		{
+			name:        "key replaced by an earlier rotation is deleted after its grace period",
+			ages:        []time.Duration{5 * time.Hour, 3 * time.Hour},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, GracePeriod: grace, DeleteOld: true},
+			wantDeleted: 1,
+			wantPending: 1,
+		},
+		{
+			name:        "key replaced by an earlier rotation within its grace period is pending",
+			ages:        []time.Duration{5 * time.Hour, 30 * time.Minute},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, GracePeriod: grace, DeleteOld: true},
+			wantPending: 2,
+		},
+		{
+			name:        "keys are kept without DeleteOld",
+			ages:        []time.Duration{5 * time.Hour, 3 * time.Hour},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, GracePeriod: grace},
+			wantPending: 2,
+		},
+		{
+			name:        "key without expiry is never deleted by DeleteOld",
+			ages:        []time.Duration{noExpiry, 3 * time.Hour},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, DeleteOld: true},
+			wantDeleted: 1,
+			wantPending: 1,
+		},
+		{
+			name:        "keys are never deleted by DeleteOld without lifetime",
+			ages:        []time.Duration{5 * time.Hour, 3 * time.Hour},
+			opts:        services.S3AccessKeyRotateOptions{DeleteOld: true},
+			wantPending: 2,
+		},
+		{
+			name:        "KeepMax deletes the oldest keys regardless of their age",
+			ages:        []time.Duration{noExpiry, 30 * time.Minute},
+			opts:        services.S3AccessKeyRotateOptions{Lifetime: lifetime, GracePeriod: grace, KeepMax: 2},
+			wantDeleted: 1,
+			wantPending: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, tenantClient := newTenant(t)
+			userId := newUser(t, tenantClient, "user/rotate")
+			keys := tenantClient.S3AccessKeys()
+
+			now := time.Now().UTC()
+			for _, age := range tt.ages {
+				key := &models.S3AccessKey{}
+				if age >= 0 {
+					expires := now.Add(lifetime - age)
+					key.Expires = &expires
+				}
+				_, err := keys.CreateForUser(ctx, userId, key)
+				if err != nil {
+					t.Fatalf("Failed to create key: %v", err)
+				}
+			}
+
+			rotation, err := keys.RotateForUser(ctx, userId, &tt.opts)
+			if err != nil {
+				t.Fatalf("Failed to rotate: %v", err)
+			}
+
+			if len(rotation.Deleted) != tt.wantDeleted || len(rotation.Pending) != tt.wantPending {
+				t.Fatalf("Expected %d deleted and %d pending keys, got %d and %d", tt.wantDeleted, tt.wantPending, len(rotation.Deleted), len(rotation.Pending))
+			}
+
+			remaining, err := keys.ListForUser(ctx, userId)
+			if err != nil {
+				t.Fatalf("Failed to list keys: %v", err)
+			}
+			if want := len(tt.ages) + 1 - tt.wantDeleted; len(*remaining) != want {
+				t.Errorf("Expected %d remaining keys, got %d", want, len(*remaining))
+			}
+		})
+	}
+}
+
+func TestRotateForUser_ReplacedKeyDeadline(t *testing.T) {
+	ctx := context.Background()
+	_, tenantClient := newTenant(t)
+	userId := newUser(t, tenantClient, "user/rotate")
+	keys := tenantClient.S3AccessKeys()
+
+	// created ten hours ago, far longer ago than the grace period
+	expires := time.Now().UTC().Add(14 * time.Hour)
+	_, err := keys.CreateForUser(ctx, userId, &models.S3AccessKey{Expires: &expires})
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+
+	before := time.Now()
+	rotation, err := keys.RotateForUser(ctx, userId, &services.S3AccessKeyRotateOptions{Lifetime: 24 * time.Hour, GracePeriod: time.Hour, DeleteOld: true})
+	if err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+
+	if len(rotation.Pending) != 1 {
+		t.Fatalf("Expected 1 pending key, got %d", len(rotation.Pending))
+	}
+	if deadline := rotation.Pending[0].DeleteAfter; deadline.Before(before.Add(time.Hour)) {
+		t.Errorf("Expected the grace period to start at the rotation, deadline is %s", deadline)
+	}
+}
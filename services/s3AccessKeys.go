@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
 )
@@ -22,10 +24,30 @@ type S3AccessKeyServiceInterface interface {
 	CreateForUser(ctx context.Context, userId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error)
 	DeleteForCurrentUser(ctx context.Context, id string) error
 	DeleteForUser(ctx context.Context, userId string, id string) error
+	RotateForUser(ctx context.Context, userId string, opts *S3AccessKeyRotateOptions) (*S3AccessKeyRotation, error)
 }
 
 func getUsers3AccessKeyEndpoint(userId string) string {
-	return fmt.Sprintf(users3AccessKeyEndpoint, userId)
+	return fmt.Sprintf(users3AccessKeyEndpoint, url.PathEscape(userId))
+}
+
+// validateS3AccessKeyImport ensures that an imported key pair specifies both the access key and the secret access key
+func validateS3AccessKeyImport(s3AccessKey *models.S3AccessKey) error {
+	if !s3AccessKey.IsImport() {
+		return nil
+	}
+
+	if s3AccessKey.AccessKey == nil || *s3AccessKey.AccessKey == "" {
+		return fmt.Errorf("importing an s3 access key requires an access key")
+	}
+	if s3AccessKey.SecretAccessKey == nil || *s3AccessKey.SecretAccessKey == "" {
+		return fmt.Errorf("importing an s3 access key requires a secret access key")
+	}
+	if strings.ContainsAny(*s3AccessKey.AccessKey, " \t\r\n") || strings.ContainsAny(*s3AccessKey.SecretAccessKey, " \t\r\n") {
+		return fmt.Errorf("imported s3 access keys must not contain whitespace")
+	}
+
+	return nil
 }
 
 type S3AccessKeyService struct {
@@ -65,7 +87,7 @@ func (s *S3AccessKeyService) ListForUser(ctx context.Context, userId string) (*[
 func (s *S3AccessKeyService) GetByIdForCurrentUser(ctx context.Context, id string) (*models.S3AccessKey, error) {
 	response := models.Response{}
 	response.Data = &models.S3AccessKey{}
-	err := s.client.DoParsed(ctx, "GET", currentUsers3AccessKeyEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(currentUsers3AccessKeyEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +100,7 @@ func (s *S3AccessKeyService) GetByIdForCurrentUser(ctx context.Context, id strin
 func (s *S3AccessKeyService) GetByIdForUser(ctx context.Context, userId string, id string) (*models.S3AccessKey, error) {
 	response := models.Response{}
 	response.Data = &models.S3AccessKey{}
-	err := s.client.DoParsed(ctx, "GET", getUsers3AccessKeyEndpoint(userId)+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(getUsers3AccessKeyEndpoint(userId), id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -88,10 +110,16 @@ func (s *S3AccessKeyService) GetByIdForUser(ctx context.Context, userId string,
 	return s3AccessKey, nil
 }
 
+// CreateForCurrentUser creates a key pair for the current user. If AccessKey and SecretAccessKey are set, the given key pair is imported.
 func (s *S3AccessKeyService) CreateForCurrentUser(ctx context.Context, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	err := validateS3AccessKeyImport(s3AccessKey)
+	if err != nil {
+		return nil, err
+	}
+
 	response := models.Response{}
 	response.Data = &models.S3AccessKey{}
-	err := s.client.DoParsed(ctx, "POST", currentUsers3AccessKeyEndpoint, s3AccessKey, &response)
+	err = s.client.DoParsed(ctx, "POST", currentUsers3AccessKeyEndpoint, s3AccessKey, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +129,17 @@ func (s *S3AccessKeyService) CreateForCurrentUser(ctx context.Context, s3AccessK
 	return s3AccessKey, nil
 }
 
+// CreateForUser creates a key pair for the given user. If AccessKey and SecretAccessKey are set, the given key pair is imported,
+// e.g. to keep credentials identical across grids of a grid federation.
 func (s *S3AccessKeyService) CreateForUser(ctx context.Context, userId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	err := validateS3AccessKeyImport(s3AccessKey)
+	if err != nil {
+		return nil, err
+	}
+
 	response := models.Response{}
 	response.Data = &models.S3AccessKey{}
-	err := s.client.DoParsed(ctx, "POST", getUsers3AccessKeyEndpoint(userId), s3AccessKey, &response)
+	err = s.client.DoParsed(ctx, "POST", getUsers3AccessKeyEndpoint(userId), s3AccessKey, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +150,7 @@ func (s *S3AccessKeyService) CreateForUser(ctx context.Context, userId string, s
 }
 
 func (s *S3AccessKeyService) DeleteForCurrentUser(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", currentUsers3AccessKeyEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(currentUsers3AccessKeyEndpoint, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -124,7 +159,7 @@ func (s *S3AccessKeyService) DeleteForCurrentUser(ctx context.Context, id string
 }
 
 func (s *S3AccessKeyService) DeleteForUser(ctx context.Context, userId string, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", getUsers3AccessKeyEndpoint(userId)+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(getUsers3AccessKeyEndpoint(userId), id), nil, nil)
 	if err != nil {
 		return err
 	}
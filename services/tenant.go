@@ -7,17 +7,25 @@ import (
 )
 
 const (
-	tenantEndpoint string = "/grid/accounts"
+	tenantEndpoint        string = "/grid/accounts"
+	deletedTenantEndpoint string = "/grid/deleted-accounts"
 )
 
 // TenantServiceInterface defines the contract for tenant service operations
 type TenantServiceInterface interface {
-	List(ctx context.Context) (*[]models.Tenant, error)
+	List(ctx context.Context, opts ...ListOption) (*[]models.Tenant, error)
+	Iter(opts ...ListOption) *ListIter[models.Tenant]
 	GetById(ctx context.Context, id string) (*models.Tenant, error)
 	Create(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error)
 	Update(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error)
 	Delete(ctx context.Context, id string) error
 	GetUsage(ctx context.Context, id string) (*models.TenantUsage, error)
+	ResetRootPassword(ctx context.Context, id string, password string) error
+	ListDeleted(ctx context.Context) (*[]models.DeletedTenant, error)
+	GetRootUser(ctx context.Context, id string) (*models.User, error)
+	UpdateRootUser(ctx context.Context, id string, user *models.User) (*models.User, error)
+	DisableRootUser(ctx context.Context, id string) error
+	EnableRootUser(ctx context.Context, id string) error
 }
 
 type TenantService struct {
@@ -28,10 +36,12 @@ func NewTenantService(client HTTPClient) *TenantService {
 	return &TenantService{client: client}
 }
 
-func (s *TenantService) List(ctx context.Context) (*[]models.Tenant, error) {
+// List returns a single page of tenants. Without options the server returns its default page.
+// Use Iter to read all tenants across pages.
+func (s *TenantService) List(ctx context.Context, opts ...ListOption) (*[]models.Tenant, error) {
 	response := models.Response{}
 	response.Data = &[]models.Tenant{}
-	err := s.client.DoParsed(ctx, "GET", tenantEndpoint, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", tenantEndpoint, nil, &response, WithQuery(newListOptions(opts...).Query()))
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +51,17 @@ func (s *TenantService) List(ctx context.Context) (*[]models.Tenant, error) {
 	return tenants, nil
 }
 
+// Iter returns an iterator over all tenants, fetching pages of WithLimit items (default 100).
+func (s *TenantService) Iter(opts ...ListOption) *ListIter[models.Tenant] {
+	return NewListIter(s.List, func(tenant models.Tenant) string {
+		return tenant.Id
+	}, opts...)
+}
+
 func (s *TenantService) GetById(ctx context.Context, id string) (*models.Tenant, error) {
 	response := models.Response{}
 	response.Data = &models.Tenant{}
-	err := s.client.DoParsed(ctx, "GET", tenantEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(tenantEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +87,7 @@ func (s *TenantService) Create(ctx context.Context, tenant *models.Tenant) (*mod
 func (s *TenantService) Update(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
 	response := models.Response{}
 	response.Data = &models.Tenant{}
-	err := s.client.DoParsed(ctx, "PUT", tenantEndpoint+"/"+tenant.Id, tenant, &response)
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(tenantEndpoint, tenant.Id), tenant, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +98,7 @@ func (s *TenantService) Update(ctx context.Context, tenant *models.Tenant) (*mod
 }
 
 func (s *TenantService) Delete(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", tenantEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(tenantEndpoint, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -93,7 +110,7 @@ func (s *TenantService) GetUsage(ctx context.Context, id string) (*models.Tenant
 	response := models.Response{}
 	response.Data = &models.TenantUsage{}
 
-	err := s.client.DoParsed(ctx, "GET", tenantEndpoint+"/"+id+"/usage", nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(tenantEndpoint, id, "usage"), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -101,3 +118,81 @@ func (s *TenantService) GetUsage(ctx context.Context, id string) (*models.Tenant
 	usage := response.Data.(*models.TenantUsage)
 	return usage, nil
 }
+
+// ResetRootPassword sets a new password for the root user of the tenant account.
+func (s *TenantService) ResetRootPassword(ctx context.Context, id string, password string) error {
+	data := map[string]string{"password": password}
+	err := s.client.DoParsed(ctx, "POST", resourcePath(tenantEndpoint, id, "change-password"), data, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListDeleted lists deleted tenant accounts whose data has not been fully removed yet.
+func (s *TenantService) ListDeleted(ctx context.Context) (*[]models.DeletedTenant, error) {
+	response := models.Response{}
+	response.Data = &[]models.DeletedTenant{}
+	err := s.client.DoParsed(ctx, "GET", deletedTenantEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := response.Data.(*[]models.DeletedTenant)
+
+	return tenants, nil
+}
+
+func (s *TenantService) GetRootUser(ctx context.Context, id string) (*models.User, error) {
+	response := models.Response{}
+	response.Data = &models.User{}
+	err := s.client.DoParsed(ctx, "GET", resourcePath(tenantEndpoint, id, "root-user"), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	user := response.Data.(*models.User)
+
+	return user, nil
+}
+
+func (s *TenantService) UpdateRootUser(ctx context.Context, id string, user *models.User) (*models.User, error) {
+	response := models.Response{}
+	response.Data = &models.User{}
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(tenantEndpoint, id, "root-user"), user, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	user = response.Data.(*models.User)
+
+	return user, nil
+}
+
+// DisableRootUser prevents the root user of the tenant account from signing in.
+// The account itself is not disabled: its other users and their S3 access keys keep working.
+func (s *TenantService) DisableRootUser(ctx context.Context, id string) error {
+	return s.setRootUserDisabled(ctx, id, true)
+}
+
+// EnableRootUser allows the root user of the tenant account to sign in again.
+func (s *TenantService) EnableRootUser(ctx context.Context, id string) error {
+	return s.setRootUserDisabled(ctx, id, false)
+}
+
+func (s *TenantService) setRootUserDisabled(ctx context.Context, id string, disable bool) error {
+	user, err := s.GetRootUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	user.Disable = &disable
+
+	_, err = s.UpdateRootUser(ctx, id, user)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
@@ -9,30 +12,49 @@ import (
 
 const (
 	tenantGroupEndpoint string = "/org/groups"
+
+	localGroupPrefix     string = "group/"
+	federatedGroupPrefix string = "federated-group/"
+
+	// membershipUpdateAttempts is the number of times a membership change is written when it was lost to a concurrent update
+	membershipUpdateAttempts = 3
 )
 
+// ErrMembershipConflict is returned when a membership change kept being overwritten by concurrent updates of the user.
+var ErrMembershipConflict = errors.New("group membership was modified concurrently")
+
 // TenantGroupServiceInterface defines the contract for tenant group service operations
 type TenantGroupServiceInterface interface {
-	List(ctx context.Context) (*[]models.TenantGroup, error)
+	List(ctx context.Context, opts ...ListOption) (*[]models.TenantGroup, error)
+	Iter(opts ...ListOption) *ListIter[models.TenantGroup]
 	GetById(ctx context.Context, id string) (*models.TenantGroup, error)
 	GetByName(ctx context.Context, name string) (*models.TenantGroup, error)
 	Create(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error)
+	CreateFederated(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error)
 	Update(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error)
 	Delete(ctx context.Context, id string) error
+	ListMembers(ctx context.Context, groupId string) (*[]models.User, error)
+	AddMember(ctx context.Context, groupId string, userId string) error
+	RemoveMember(ctx context.Context, groupId string, userId string) error
+	SetMembers(ctx context.Context, groupId string, userIds []string) error
+	ResolveIds(ctx context.Context, names []string) ([]string, error)
 }
 
 type TenantGroupService struct {
 	client HTTPClient
+	users  TenantUserServiceInterface
 }
 
 func NewTenantGroupService(client HTTPClient) *TenantGroupService {
-	return &TenantGroupService{client: client}
+	return &TenantGroupService{client: client, users: NewTenantUserService(client)}
 }
 
-func (s *TenantGroupService) List(ctx context.Context) (*[]models.TenantGroup, error) {
+// List returns a single page of groups. Without options the server returns its default page.
+// Use Iter to read all groups across pages.
+func (s *TenantGroupService) List(ctx context.Context, opts ...ListOption) (*[]models.TenantGroup, error) {
 	response := models.Response{}
 	response.Data = &[]models.TenantGroup{}
-	err := s.client.DoParsed(ctx, "GET", tenantGroupEndpoint, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", tenantGroupEndpoint, nil, &response, WithQuery(newListOptions(opts...).Query()))
 	if err != nil {
 		return nil, err
 	}
@@ -42,10 +64,20 @@ func (s *TenantGroupService) List(ctx context.Context) (*[]models.TenantGroup, e
 	return groups, nil
 }
 
+// Iter returns an iterator over all groups, fetching pages of WithLimit items (default 100).
+func (s *TenantGroupService) Iter(opts ...ListOption) *ListIter[models.TenantGroup] {
+	return NewListIter(s.List, func(group models.TenantGroup) string {
+		if group.Id == nil {
+			return ""
+		}
+		return *group.Id
+	}, opts...)
+}
+
 func (s *TenantGroupService) GetById(ctx context.Context, id string) (*models.TenantGroup, error) {
 	response := models.Response{}
 	response.Data = &models.TenantGroup{}
-	err := s.client.DoParsed(ctx, "GET", tenantGroupEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(tenantGroupEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -55,10 +87,15 @@ func (s *TenantGroupService) GetById(ctx context.Context, id string) (*models.Te
 	return group, nil
 }
 
+// GetByName returns the group with the given unique name. Names without a "group/" or "federated-group/" prefix are treated as local group names.
 func (s *TenantGroupService) GetByName(ctx context.Context, name string) (*models.TenantGroup, error) {
+	if !strings.HasPrefix(name, localGroupPrefix) && !strings.HasPrefix(name, federatedGroupPrefix) {
+		name = localGroupPrefix + name
+	}
+
 	response := models.Response{}
 	response.Data = &models.TenantGroup{}
-	err := s.client.DoParsed(ctx, "GET", tenantGroupEndpoint+"/group/"+name, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", uniqueNamePath(tenantGroupEndpoint, name), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -69,11 +106,38 @@ func (s *TenantGroupService) GetByName(ctx context.Context, name string) (*model
 }
 
 func (s *TenantGroupService) Create(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
+	// federated groups are created through CreateFederated, which checks their unique name
+	if strings.HasPrefix(group.UniqueName, federatedGroupPrefix) {
+		return s.CreateFederated(ctx, group)
+	}
+
 	// enforce group/ prefix on group.uniqueName if manually created
-	if !strings.HasPrefix(group.UniqueName, "group/") {
-		group.UniqueName = "group/" + group.UniqueName
+	if !strings.HasPrefix(group.UniqueName, localGroupPrefix) {
+		group.UniqueName = localGroupPrefix + group.UniqueName
+	}
+
+	return s.create(ctx, group)
+}
+
+// CreateFederated imports a group from the identity source used by the tenant, its own or the grid's. The unique name
+// is prefixed with "federated-group/" if necessary and the display name is imported by StorageGRID.
+func (s *TenantGroupService) CreateFederated(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
+	name := strings.TrimPrefix(group.UniqueName, federatedGroupPrefix)
+	if name == "" || strings.HasPrefix(name, localGroupPrefix) {
+		return nil, fmt.Errorf("invalid federated group name %q", group.UniqueName)
 	}
 
+	group.UniqueName = federatedGroupPrefix + name
+
+	created, err := s.create(ctx, group)
+	if err != nil {
+		return nil, identitySourceHint(ctx, s.client, err)
+	}
+
+	return created, nil
+}
+
+func (s *TenantGroupService) create(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
 	response := models.Response{}
 	response.Data = &models.TenantGroup{}
 	err := s.client.DoParsed(ctx, "POST", tenantGroupEndpoint, group, &response)
@@ -89,7 +153,7 @@ func (s *TenantGroupService) Create(ctx context.Context, group *models.TenantGro
 func (s *TenantGroupService) Update(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
 	response := models.Response{}
 	response.Data = &models.TenantGroup{}
-	err := s.client.DoParsed(ctx, "PUT", tenantGroupEndpoint+"/"+*group.Id, group, &response)
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(tenantGroupEndpoint, *group.Id), group, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +164,142 @@ func (s *TenantGroupService) Update(ctx context.Context, group *models.TenantGro
 }
 
 func (s *TenantGroupService) Delete(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", tenantGroupEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(tenantGroupEndpoint, id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListMembers returns the users that are a member of the group.
+func (s *TenantGroupService) ListMembers(ctx context.Context, groupId string) (*[]models.User, error) {
+	users, err := s.users.Iter().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []models.User{}
+	for _, user := range *users {
+		if slices.Contains(user.MemberOf, groupId) {
+			members = append(members, user)
+		}
+	}
+
+	return &members, nil
+}
+
+// AddMember adds the user to the group. Adding an existing member is a no-op.
+func (s *TenantGroupService) AddMember(ctx context.Context, groupId string, userId string) error {
+	return s.updateMembership(ctx, userId, func(memberOf []string) []string {
+		if slices.Contains(memberOf, groupId) {
+			return memberOf
+		}
+		return append(memberOf, groupId)
+	})
+}
+
+// RemoveMember removes the user from the group. Removing a non-member is a no-op.
+func (s *TenantGroupService) RemoveMember(ctx context.Context, groupId string, userId string) error {
+	return s.updateMembership(ctx, userId, func(memberOf []string) []string {
+		return slices.DeleteFunc(memberOf, func(id string) bool { return id == groupId })
+	})
+}
+
+// SetMembers makes the given users the only members of the group.
+func (s *TenantGroupService) SetMembers(ctx context.Context, groupId string, userIds []string) error {
+	members, err := s.ListMembers(ctx, groupId)
 	if err != nil {
 		return err
 	}
 
+	current := []string{}
+	for _, member := range *members {
+		if member.Id == nil {
+			continue
+		}
+		current = append(current, *member.Id)
+
+		if !slices.Contains(userIds, *member.Id) {
+			err = s.RemoveMember(ctx, groupId, *member.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, userId := range userIds {
+		if slices.Contains(current, userId) {
+			continue
+		}
+
+		err = s.AddMember(ctx, groupId, userId)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// ResolveIds returns the IDs of the groups with the given unique names, in the same order.
+// Names without a "group/" or "federated-group/" prefix are treated as local group names.
+func (s *TenantGroupService) ResolveIds(ctx context.Context, names []string) ([]string, error) {
+	groups, err := s.Iter().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]string, len(*groups))
+	for _, group := range *groups {
+		if group.Id != nil {
+			byName[group.UniqueName] = *group.Id
+		}
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		if !strings.HasPrefix(name, localGroupPrefix) && !strings.HasPrefix(name, federatedGroupPrefix) {
+			name = localGroupPrefix + name
+		}
+
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("group with name %s not found", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// updateMembership applies mutate to the user's group memberships and reads the user again after the update to
+// verify the change, writing it again if a concurrent update overwrote it. The API offers no conditional updates, so
+// this is best-effort: a concurrent update of the same user that lands between the read and the write is lost.
+func (s *TenantGroupService) updateMembership(ctx context.Context, userId string, mutate func([]string) []string) error {
+	for attempt := 0; ; attempt++ {
+		user, err := s.users.GetById(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		if user.Federated != nil && *user.Federated {
+			return fmt.Errorf("group memberships of federated user %s are managed by the identity source", user.UniqueName)
+		}
+
+		after := mutate(slices.Clone(user.MemberOf))
+		if slices.Equal(user.MemberOf, after) {
+			return nil
+		}
+
+		if attempt == membershipUpdateAttempts {
+			return fmt.Errorf("failed to update group memberships of user %s: %w", userId, ErrMembershipConflict)
+		}
+
+		user.MemberOf = after
+		_, err = s.users.Update(ctx, user)
+		if err != nil {
+			return err
+		}
+	}
+}
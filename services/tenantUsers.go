@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/yehlo/storagegrid-sdk-go/models"
@@ -9,14 +10,19 @@ import (
 
 const (
 	tenantUserEndpoint string = "/org/users"
+
+	localUserPrefix     string = "user/"
+	federatedUserPrefix string = "federated-user/"
 )
 
 // TenantUserServiceInterface defines the contract for tenant user service operations
 type TenantUserServiceInterface interface {
-	List(ctx context.Context) (*[]models.User, error)
+	List(ctx context.Context, opts ...ListOption) (*[]models.User, error)
+	Iter(opts ...ListOption) *ListIter[models.User]
 	GetById(ctx context.Context, id string) (*models.User, error)
 	GetByName(ctx context.Context, name string) (*models.User, error)
 	Create(ctx context.Context, user *models.User) (*models.User, error)
+	CreateFederated(ctx context.Context, user *models.User) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
 	Delete(ctx context.Context, id string) error
 	SetPassword(ctx context.Context, id string, password string) error
@@ -30,10 +36,12 @@ func NewTenantUserService(client HTTPClient) *TenantUserService {
 	return &TenantUserService{client: client}
 }
 
-func (s *TenantUserService) List(ctx context.Context) (*[]models.User, error) {
+// List returns a single page of users. Without options the server returns its default page.
+// Use Iter to read all users across pages.
+func (s *TenantUserService) List(ctx context.Context, opts ...ListOption) (*[]models.User, error) {
 	response := models.Response{}
 	response.Data = &[]models.User{}
-	err := s.client.DoParsed(ctx, "GET", tenantUserEndpoint, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", tenantUserEndpoint, nil, &response, WithQuery(newListOptions(opts...).Query()))
 	if err != nil {
 		return nil, err
 	}
@@ -43,10 +51,20 @@ func (s *TenantUserService) List(ctx context.Context) (*[]models.User, error) {
 	return users, nil
 }
 
+// Iter returns an iterator over all users, fetching pages of WithLimit items (default 100).
+func (s *TenantUserService) Iter(opts ...ListOption) *ListIter[models.User] {
+	return NewListIter(s.List, func(user models.User) string {
+		if user.Id == nil {
+			return ""
+		}
+		return *user.Id
+	}, opts...)
+}
+
 func (s *TenantUserService) GetById(ctx context.Context, id string) (*models.User, error) {
 	response := models.Response{}
 	response.Data = &models.User{}
-	err := s.client.DoParsed(ctx, "GET", tenantUserEndpoint+"/"+id, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", resourcePath(tenantUserEndpoint, id), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -56,10 +74,15 @@ func (s *TenantUserService) GetById(ctx context.Context, id string) (*models.Use
 	return user, nil
 }
 
+// GetByName returns the user with the given unique name. Names without a "user/" or "federated-user/" prefix are treated as local user names.
 func (s *TenantUserService) GetByName(ctx context.Context, name string) (*models.User, error) {
+	if !strings.HasPrefix(name, localUserPrefix) && !strings.HasPrefix(name, federatedUserPrefix) {
+		name = localUserPrefix + name
+	}
+
 	response := models.Response{}
 	response.Data = &models.User{}
-	err := s.client.DoParsed(ctx, "GET", tenantUserEndpoint+"/user/"+name, nil, &response)
+	err := s.client.DoParsed(ctx, "GET", uniqueNamePath(tenantUserEndpoint, name), nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -70,11 +93,40 @@ func (s *TenantUserService) GetByName(ctx context.Context, name string) (*models
 }
 
 func (s *TenantUserService) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	// federated users are created through CreateFederated, which checks their unique name
+	if strings.HasPrefix(user.UniqueName, federatedUserPrefix) {
+		return s.CreateFederated(ctx, user)
+	}
+
 	// enforce user/ prefix on user.uniqueName if manually created
-	if !strings.HasPrefix(user.UniqueName, "user/") {
-		user.UniqueName = "user/" + user.UniqueName
+	if !strings.HasPrefix(user.UniqueName, localUserPrefix) {
+		user.UniqueName = localUserPrefix + user.UniqueName
 	}
 
+	return s.create(ctx, user)
+}
+
+// CreateFederated pre-creates a user of the identity source used by the tenant, its own or the grid's, e.g. to hand
+// out S3 access keys before the user signs in for the first time. The unique name is prefixed with "federated-user/"
+// if necessary.
+// Group memberships of federated users are imported from the identity source.
+func (s *TenantUserService) CreateFederated(ctx context.Context, user *models.User) (*models.User, error) {
+	name := strings.TrimPrefix(user.UniqueName, federatedUserPrefix)
+	if name == "" || strings.HasPrefix(name, localUserPrefix) {
+		return nil, fmt.Errorf("invalid federated user name %q", user.UniqueName)
+	}
+
+	user.UniqueName = federatedUserPrefix + name
+
+	created, err := s.create(ctx, user)
+	if err != nil {
+		return nil, identitySourceHint(ctx, s.client, err)
+	}
+
+	return created, nil
+}
+
+func (s *TenantUserService) create(ctx context.Context, user *models.User) (*models.User, error) {
 	response := models.Response{}
 	response.Data = &models.User{}
 	err := s.client.DoParsed(ctx, "POST", tenantUserEndpoint, user, &response)
@@ -90,7 +142,7 @@ func (s *TenantUserService) Create(ctx context.Context, user *models.User) (*mod
 func (s *TenantUserService) Update(ctx context.Context, user *models.User) (*models.User, error) {
 	response := models.Response{}
 	response.Data = &models.User{}
-	err := s.client.DoParsed(ctx, "PUT", tenantUserEndpoint+"/"+*user.Id, user, &response)
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(tenantUserEndpoint, *user.Id), user, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +153,7 @@ func (s *TenantUserService) Update(ctx context.Context, user *models.User) (*mod
 }
 
 func (s *TenantUserService) Delete(ctx context.Context, id string) error {
-	err := s.client.DoParsed(ctx, "DELETE", tenantUserEndpoint+"/"+id, nil, nil)
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(tenantUserEndpoint, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -111,7 +163,7 @@ func (s *TenantUserService) Delete(ctx context.Context, id string) error {
 
 func (s *TenantUserService) SetPassword(ctx context.Context, id string, password string) error {
 	data := map[string]string{"password": password}
-	err := s.client.DoParsed(ctx, "POST", tenantUserEndpoint+"/"+id+"/change-password", data, nil)
+	err := s.client.DoParsed(ctx, "POST", resourcePath(tenantUserEndpoint, id, "change-password"), data, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	trafficClassificationEndpoint        string = "/grid/traffic-classes/policies"
+	trafficClassificationMetricsEndpoint string = trafficClassificationEndpoint + "/%s/metrics"
+)
+
+// TrafficClassificationServiceInterface defines the contract for traffic classification policy service operations
+type TrafficClassificationServiceInterface interface {
+	List(ctx context.Context) (*[]models.TrafficClassificationPolicy, error)
+	GetById(ctx context.Context, id string) (*models.TrafficClassificationPolicy, error)
+	Create(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error)
+	Update(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error)
+	Delete(ctx context.Context, id string) error
+	GetMetrics(ctx context.Context, id string) (*models.TrafficClassificationMetrics, error)
+}
+
+func getTrafficClassificationMetricsEndpoint(id string) string {
+	return fmt.Sprintf(trafficClassificationMetricsEndpoint, url.PathEscape(id))
+}
+
+type TrafficClassificationService struct {
+	client HTTPClient
+}
+
+func NewTrafficClassificationService(client HTTPClient) *TrafficClassificationService {
+	return &TrafficClassificationService{client: client}
+}
+
+func (s *TrafficClassificationService) List(ctx context.Context) (*[]models.TrafficClassificationPolicy, error) {
+	response := models.Response{}
+	response.Data = &[]models.TrafficClassificationPolicy{}
+	err := s.client.DoParsed(ctx, "GET", trafficClassificationEndpoint, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := response.Data.(*[]models.TrafficClassificationPolicy)
+
+	return policies, nil
+}
+
+func (s *TrafficClassificationService) GetById(ctx context.Context, id string) (*models.TrafficClassificationPolicy, error) {
+	response := models.Response{}
+	response.Data = &models.TrafficClassificationPolicy{}
+	err := s.client.DoParsed(ctx, "GET", resourcePath(trafficClassificationEndpoint, id), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := response.Data.(*models.TrafficClassificationPolicy)
+
+	return policy, nil
+}
+
+func (s *TrafficClassificationService) Create(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error) {
+	response := models.Response{}
+	response.Data = &models.TrafficClassificationPolicy{}
+	err := s.client.DoParsed(ctx, "POST", trafficClassificationEndpoint, policy, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	policy = response.Data.(*models.TrafficClassificationPolicy)
+
+	return policy, nil
+}
+
+func (s *TrafficClassificationService) Update(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error) {
+	response := models.Response{}
+	response.Data = &models.TrafficClassificationPolicy{}
+	err := s.client.DoParsed(ctx, "PUT", resourcePath(trafficClassificationEndpoint, policy.Id), policy, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	policy = response.Data.(*models.TrafficClassificationPolicy)
+
+	return policy, nil
+}
+
+func (s *TrafficClassificationService) Delete(ctx context.Context, id string) error {
+	err := s.client.DoParsed(ctx, "DELETE", resourcePath(trafficClassificationEndpoint, id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *TrafficClassificationService) GetMetrics(ctx context.Context, id string) (*models.TrafficClassificationMetrics, error) {
+	response := models.Response{}
+	response.Data = &models.TrafficClassificationMetrics{}
+	err := s.client.DoParsed(ctx, "GET", getTrafficClassificationMetricsEndpoint(id), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := response.Data.(*models.TrafficClassificationMetrics)
+
+	return metrics, nil
+}
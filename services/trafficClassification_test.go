@@ -0,0 +1,67 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+	sgTesting "github.com/yehlo/storagegrid-sdk-go/testing"
+)
+
+// The policy paths are those of the traffic-classes section of the Grid Management API v4. The metrics path is
+// the one the service has used since it was added and has not been checked against a published fixture yet, so
+// this test pins it to make any change to it deliberate.
+func TestTrafficClassificationService_Paths(t *testing.T) {
+	ctx := context.Background()
+	id := "policy 1"
+	policy := &models.TrafficClassificationPolicy{Id: id}
+
+	tests := []struct {
+		name   string
+		call   func(s *services.TrafficClassificationService) error
+		method string
+		path   string
+	}{
+		{name: "List", call: func(s *services.TrafficClassificationService) error { _, err := s.List(ctx); return err }, method: "GET", path: "/grid/traffic-classes/policies"},
+		{name: "GetById", call: func(s *services.TrafficClassificationService) error { _, err := s.GetById(ctx, id); return err }, method: "GET", path: "/grid/traffic-classes/policies/policy%201"},
+		{name: "Create", call: func(s *services.TrafficClassificationService) error { _, err := s.Create(ctx, policy); return err }, method: "POST", path: "/grid/traffic-classes/policies"},
+		{name: "Update", call: func(s *services.TrafficClassificationService) error { _, err := s.Update(ctx, policy); return err }, method: "PUT", path: "/grid/traffic-classes/policies/policy%201"},
+		{name: "Delete", call: func(s *services.TrafficClassificationService) error { return s.Delete(ctx, id) }, method: "DELETE", path: "/grid/traffic-classes/policies/policy%201"},
+		{name: "GetMetrics", call: func(s *services.TrafficClassificationService) error { _, err := s.GetMetrics(ctx, id); return err }, method: "GET", path: "/grid/traffic-classes/policies/policy%201/metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &sgTesting.MockHTTPClient{}
+			err := tt.call(services.NewTrafficClassificationService(client))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			calls := client.CallsTo("DoParsed")
+			if len(calls) != 1 || calls[0].Args[0] != tt.method || calls[0].Args[1] != tt.path {
+				t.Errorf("Expected %s %s, got %v", tt.method, tt.path, calls)
+			}
+		})
+	}
+}
+
+func TestTrafficClassificationService_GetMetrics(t *testing.T) {
+	fixture := `{"status": "success", "apiVersion": "4.0", "data": {"requestRate": 12.5, "bandwidthIn": 1048576, "concurrentReadRequests": 3, "limitedRequests": 1}}`
+
+	client := &sgTesting.MockHTTPClient{
+		DoParseFunc: func(ctx context.Context, method, path string, body interface{}, output interface{}, opts ...services.RequestOption) error {
+			return json.Unmarshal([]byte(fixture), output)
+		},
+	}
+
+	metrics, err := services.NewTrafficClassificationService(client).GetMetrics(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if metrics.RequestRate == nil || *metrics.RequestRate != 12.5 || metrics.ConcurrentReadRequests == nil || *metrics.ConcurrentReadRequests != 3 {
+		t.Errorf("Unexpected metrics %+v", metrics)
+	}
+}
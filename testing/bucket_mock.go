@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -9,6 +11,11 @@ import (
 
 // MockBucketService implements services.BucketServiceInterface for testing
 type MockBucketService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.BucketServiceInterface
+
 	ListFunc        func(ctx context.Context) (*[]models.Bucket, error)
 	GetByNameFunc   func(ctx context.Context, name string) (*models.Bucket, error)
 	CreateFunc      func(ctx context.Context, bucket *models.Bucket) (*models.Bucket, error)
@@ -19,53 +26,80 @@ type MockBucketService struct {
 }
 
 func (m *MockBucketService) List(ctx context.Context) (*[]models.Bucket, error) {
+	m.record("List")
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx)
 	}
-	return &[]models.Bucket{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.List(ctx)
+	}
+	return m.defaultList(ctx)
 }
 
 func (m *MockBucketService) GetByName(ctx context.Context, name string) (*models.Bucket, error) {
+	m.record("GetByName", name)
 	if m.GetByNameFunc != nil {
 		return m.GetByNameFunc(ctx, name)
 	}
-	return &models.Bucket{Name: name}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetByName(ctx, name)
+	}
+	return m.defaultGetByName(ctx, name)
 }
 
 func (m *MockBucketService) Create(ctx context.Context, bucket *models.Bucket) (*models.Bucket, error) {
+	m.record("Create", bucket)
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, bucket)
 	}
-	return bucket, nil
+	if m.Delegate != nil {
+		return m.Delegate.Create(ctx, bucket)
+	}
+	return m.defaultCreate(ctx, bucket)
 }
 
 func (m *MockBucketService) GetUsage(ctx context.Context, name string) (*models.BucketStats, error) {
+	m.record("GetUsage", name)
 	if m.GetUsageFunc != nil {
 		return m.GetUsageFunc(ctx, name)
 	}
-	bucketName := name
-	return &models.BucketStats{Name: &bucketName}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetUsage(ctx, name)
+	}
+	return m.defaultGetUsage(ctx, name)
 }
 
 func (m *MockBucketService) Delete(ctx context.Context, name string) error {
+	m.record("Delete", name)
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, name)
 	}
+	if m.Delegate != nil {
+		return m.Delegate.Delete(ctx, name)
+	}
 	return nil
 }
 
 func (m *MockBucketService) Drain(ctx context.Context, name string) (*models.BucketDeleteObjectStatus, error) {
+	m.record("Drain", name)
 	if m.DrainFunc != nil {
 		return m.DrainFunc(ctx, name)
 	}
-	return &models.BucketDeleteObjectStatus{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.Drain(ctx, name)
+	}
+	return m.defaultDrain(ctx, name)
 }
 
 func (m *MockBucketService) DrainStatus(ctx context.Context, name string) (*models.BucketDeleteObjectStatus, error) {
+	m.record("DrainStatus", name)
 	if m.DrainStatusFunc != nil {
 		return m.DrainStatusFunc(ctx, name)
 	}
-	return &models.BucketDeleteObjectStatus{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.DrainStatus(ctx, name)
+	}
+	return m.defaultDrainStatus(ctx, name)
 }
 
 // Compile-time interface compliance check
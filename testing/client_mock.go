@@ -3,25 +3,35 @@ package testing
 import (
 	"context"
 	"net/http"
+
+	"github.com/yehlo/storagegrid-sdk-go/services"
 )
 
 // MockHTTPClient implements the services.HTTPClient interface for testing actual service implementations
 // This allows testing the real service logic while mocking only the HTTP layer
+// It is maintained by hand, the service mocks are generated with go generate
 type MockHTTPClient struct {
-	DoParseFunc    func(ctx context.Context, method, path string, body interface{}, output interface{}) error
-	DoUnparsedFunc func(ctx context.Context, method, path string, body interface{}) (*http.Response, error)
+	CallRecorder
+
+	DoParseFunc    func(ctx context.Context, method, path string, body interface{}, output interface{}, opts ...services.RequestOption) error
+	DoUnparsedFunc func(ctx context.Context, method, path string, body interface{}, opts ...services.RequestOption) (*http.Response, error)
 }
 
-func (m *MockHTTPClient) DoParsed(ctx context.Context, method, path string, body interface{}, output interface{}) error {
+func (m *MockHTTPClient) DoParsed(ctx context.Context, method, path string, body interface{}, output interface{}, opts ...services.RequestOption) error {
+	m.record("DoParsed", method, path, body, output, opts)
 	if m.DoParseFunc != nil {
-		return m.DoParseFunc(ctx, method, path, body, output)
+		return m.DoParseFunc(ctx, method, path, body, output, opts...)
 	}
 	return nil
 }
 
-func (m *MockHTTPClient) DoUnparsed(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+func (m *MockHTTPClient) DoUnparsed(ctx context.Context, method, path string, body interface{}, opts ...services.RequestOption) (*http.Response, error) {
+	m.record("DoUnparsed", method, path, body, opts)
 	if m.DoUnparsedFunc != nil {
-		return m.DoUnparsedFunc(ctx, method, path, body)
+		return m.DoUnparsedFunc(ctx, method, path, body, opts...)
 	}
 	return &http.Response{StatusCode: 200}, nil
 }
+
+// Compile-time interface compliance check
+var _ services.HTTPClient = (*MockHTTPClient)(nil)
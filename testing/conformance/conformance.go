@@ -0,0 +1,221 @@
+// Package conformance provides a contract test suite for the service interfaces of the SDK.
+//
+// The suite walks every resource through create, get, update, list and delete and checks that the
+// results are consistent. It is parameterized by a Factory, so the same tests validate the fake grid
+// offline, a live grid when credentials are present, or any other implementation of the services. The mocks
+// of the testing package keep no state, so they only pass when they delegate to such an implementation:
+//
+//	func TestConformance(t *testing.T) {
+//		grid := fakegrid.New()
+//		defer grid.Close()
+//
+//		conformance.Run(t, conformance.Factory{
+//			Grid: func(t *testing.T) conformance.GridClient {
+//				gridClient, err := client.NewGridClient(
+//					client.WithEndpoint(grid.URL),
+//					client.WithCredentials(grid.GridCredentials()),
+//				)
+//				if err != nil {
+//					t.Fatal(err)
+//				}
+//				return gridClient
+//			},
+//			Tenant: func(t *testing.T, credentials *models.Credentials) conformance.TenantClient {
+//				tenantClient, err := client.NewTenantClient(
+//					client.WithEndpoint(grid.URL),
+//					client.WithCredentials(credentials),
+//				)
+//				if err != nil {
+//					t.Fatal(err)
+//				}
+//				return tenantClient
+//			},
+//		})
+//	}
+package conformance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+const (
+	// DefaultTimeout is the timeout of each test unless Factory.Timeout is set
+	DefaultTimeout = time.Minute
+	// DefaultPrefix is prepended to the names of created resources unless Factory.Prefix is set
+	DefaultPrefix = "conformance"
+)
+
+// GridClient is the part of client.GridClient exercised by the suite.
+type GridClient interface {
+	Tenant() services.TenantServiceInterface
+	Health() services.HealthServiceInterface
+	HAGroup() services.HAGroupServiceInterface
+	Gateway() services.GatewayConfigServiceInterface
+}
+
+// TenantClient is the part of client.TenantClient exercised by the suite.
+type TenantClient interface {
+	Bucket() services.BucketServiceInterface
+	Users() services.TenantUserServiceInterface
+	Groups() services.TenantGroupServiceInterface
+	S3AccessKeys() services.S3AccessKeyServiceInterface
+}
+
+// Factory creates the clients the suite runs against.
+type Factory struct {
+	// Grid returns a client signed in as grid administrator. It is called once per test.
+	Grid func(t *testing.T) GridClient
+	// Tenant returns a client signed in with the root credentials of a tenant account created by the suite.
+	Tenant func(t *testing.T, credentials *models.Credentials) TenantClient
+
+	// Prefix is prepended to the names of created resources. Use a unique prefix on shared grids.
+	Prefix string
+	// Region of created buckets, models.DefaultRegion if empty
+	Region string
+	// Timeout of each test, DefaultTimeout if zero
+	Timeout time.Duration
+
+	// HAGroup returns the HA group to create. Virtual IPs and interfaces depend on the grid,
+	// so the HA group tests are skipped when it is nil.
+	HAGroup func() *models.HAGroup
+	// GatewayPort is the port of the load balancer endpoint to create. The load balancer
+	// endpoint tests are skipped when it is zero.
+	GatewayPort int
+}
+
+// Run runs the whole suite as subtests of t.
+func Run(t *testing.T, f Factory) {
+	t.Helper()
+
+	if f.Grid == nil || f.Tenant == nil {
+		t.Fatal("conformance: Factory.Grid and Factory.Tenant are required")
+	}
+
+	t.Run("Health", func(t *testing.T) { testHealth(t, f) })
+	t.Run("Tenants", func(t *testing.T) { testTenants(t, f) })
+	t.Run("TenantResources", func(t *testing.T) {
+		tenantClient := newTenantFixture(t, f)
+
+		t.Run("Buckets", func(t *testing.T) { testBuckets(t, f, tenantClient) })
+		t.Run("Groups", func(t *testing.T) { testGroups(t, f, tenantClient) })
+		t.Run("Users", func(t *testing.T) { testUsers(t, f, tenantClient) })
+		t.Run("S3AccessKeys", func(t *testing.T) { testS3AccessKeys(t, f, tenantClient) })
+	})
+	t.Run("HAGroups", func(t *testing.T) { testHAGroups(t, f) })
+	t.Run("GatewayConfigs", func(t *testing.T) { testGatewayConfigs(t, f) })
+}
+
+// name returns the name of a created resource
+func (f Factory) name(suffix string) string {
+	prefix := f.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+
+	return prefix + "-" + suffix
+}
+
+func (f Factory) region() string {
+	if f.Region == "" {
+		return models.DefaultRegion
+	}
+
+	return f.Region
+}
+
+// context returns a context bound to the timeout of the factory and the lifetime of the test
+func (f Factory) context(t *testing.T) context.Context {
+	timeout := f.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+// cleanup registers a cleanup that runs with its own context, since the context of the test may be expired.
+// Tests delete their resources themselves, cleanups only catch the ones left behind by failures.
+func (f Factory) cleanup(t *testing.T, what string, fn func(ctx context.Context) error) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+		defer cancel()
+
+		err := fn(ctx)
+		if err != nil {
+			t.Errorf("failed to clean up %s: %v", what, err)
+		}
+	})
+}
+
+// newTenantFixture creates a tenant account that is deleted when t finishes and returns a root client for it
+func newTenantFixture(t *testing.T, f Factory) TenantClient {
+	t.Helper()
+
+	ctx := f.context(t)
+	gridClient := f.Grid(t)
+
+	name := f.name("tenant-resources")
+	password := newPassword(t)
+	tenant, err := gridClient.Tenant().Create(ctx, &models.Tenant{
+		Name:         &name,
+		Capabilities: []string{"management", "s3"},
+		Password:     &password,
+	})
+	if err != nil {
+		t.Fatalf("failed to create tenant %s: %v", name, err)
+	}
+	f.cleanup(t, "tenant "+tenant.Id, func(ctx context.Context) error {
+		return gridClient.Tenant().Delete(ctx, tenant.Id)
+	})
+
+	accountId := tenant.Id
+	return f.Tenant(t, &models.Credentials{Username: models.TenantRootUsername, Password: password, AccountId: &accountId})
+}
+
+// newPassword returns a random password that satisfies the password rules of StorageGRID
+func newPassword(t *testing.T) string {
+	t.Helper()
+
+	random := make([]byte, 8)
+	_, err := rand.Read(random)
+	if err != nil {
+		t.Fatalf("failed to generate password: %v", err)
+	}
+
+	return "Conformance-" + hex.EncodeToString(random)
+}
+
+// expectDeleted fails the test unless getting a deleted resource failed
+func expectDeleted(t *testing.T, what string, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("%s still exists after delete", what)
+	}
+}
+
+func containsFunc[T any](items *[]T, match func(T) bool) bool {
+	if items == nil {
+		return false
+	}
+
+	for _, item := range *items {
+		if match(item) {
+			return true
+		}
+	}
+
+	return false
+}
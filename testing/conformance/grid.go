@@ -0,0 +1,251 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func testHealth(t *testing.T, f Factory) {
+	ctx := f.context(t)
+	gridClient := f.Grid(t)
+
+	health, err := gridClient.Health().Get(ctx)
+	if err != nil {
+		t.Fatalf("failed to get health: %v", err)
+	}
+	if health == nil {
+		t.Fatal("health is nil")
+	}
+}
+
+func testTenants(t *testing.T, f Factory) {
+	ctx := f.context(t)
+	gridClient := f.Grid(t)
+	tenants := gridClient.Tenant()
+
+	// create
+	name := f.name("tenant")
+	password := newPassword(t)
+	created, err := tenants.Create(ctx, &models.Tenant{
+		Name:         &name,
+		Capabilities: []string{"management", "s3"},
+		Password:     &password,
+	})
+	if err != nil {
+		t.Fatalf("failed to create tenant %s: %v", name, err)
+	}
+	if created.Id == "" {
+		t.Fatal("created tenant has no id")
+	}
+	deleted := false
+	f.cleanup(t, "tenant "+created.Id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return tenants.Delete(ctx, created.Id)
+	})
+
+	// get
+	tenant, err := tenants.GetById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get tenant %s: %v", created.Id, err)
+	}
+	if models.Deref(tenant.Name) != name {
+		t.Errorf("tenant name is %q, expected %q", models.Deref(tenant.Name), name)
+	}
+
+	// update
+	description := "updated by the conformance suite"
+	tenant.Description = &description
+	_, err = tenants.Update(ctx, tenant)
+	if err != nil {
+		t.Fatalf("failed to update tenant %s: %v", created.Id, err)
+	}
+
+	tenant, err = tenants.GetById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get tenant %s: %v", created.Id, err)
+	}
+	if models.Deref(tenant.Description) != description {
+		t.Errorf("tenant description is %q after update, expected %q", models.Deref(tenant.Description), description)
+	}
+
+	// list
+	all, err := tenants.Iter().All(ctx)
+	if err != nil {
+		t.Fatalf("failed to list tenants: %v", err)
+	}
+	if !containsFunc(all, func(tenant models.Tenant) bool { return tenant.Id == created.Id }) {
+		t.Errorf("tenant %s is not listed", created.Id)
+	}
+
+	// delete
+	err = tenants.Delete(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to delete tenant %s: %v", created.Id, err)
+	}
+	deleted = true
+
+	_, err = tenants.GetById(ctx, created.Id)
+	expectDeleted(t, "tenant "+created.Id, err)
+}
+
+func testHAGroups(t *testing.T, f Factory) {
+	if f.HAGroup == nil {
+		t.Skip("no HA group configured")
+	}
+
+	ctx := f.context(t)
+	gridClient := f.Grid(t)
+	haGroups := gridClient.HAGroup()
+
+	// create
+	name := f.name("ha-group")
+	group := f.HAGroup()
+	group.Name = &name
+	created, err := haGroups.Create(ctx, group)
+	if err != nil {
+		t.Fatalf("failed to create HA group %s: %v", name, err)
+	}
+	if created.Id == "" {
+		t.Fatal("created HA group has no id")
+	}
+	deleted := false
+	f.cleanup(t, "HA group "+created.Id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return haGroups.Delete(ctx, created.Id)
+	})
+
+	// get
+	haGroup, err := haGroups.GetById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get HA group %s: %v", created.Id, err)
+	}
+	if models.Deref(haGroup.Name) != name {
+		t.Errorf("HA group name is %q, expected %q", models.Deref(haGroup.Name), name)
+	}
+
+	// update
+	description := "updated by the conformance suite"
+	haGroup.Description = &description
+	_, err = haGroups.Update(ctx, haGroup)
+	if err != nil {
+		t.Fatalf("failed to update HA group %s: %v", created.Id, err)
+	}
+
+	haGroup, err = haGroups.GetById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get HA group %s: %v", created.Id, err)
+	}
+	if models.Deref(haGroup.Description) != description {
+		t.Errorf("HA group description is %q after update, expected %q", models.Deref(haGroup.Description), description)
+	}
+
+	// list
+	all, err := haGroups.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list HA groups: %v", err)
+	}
+	if !containsFunc(all, func(group models.HAGroup) bool { return group.Id == created.Id }) {
+		t.Errorf("HA group %s is not listed", created.Id)
+	}
+
+	// delete
+	err = haGroups.Delete(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to delete HA group %s: %v", created.Id, err)
+	}
+	deleted = true
+
+	_, err = haGroups.GetById(ctx, created.Id)
+	expectDeleted(t, "HA group "+created.Id, err)
+}
+
+func testGatewayConfigs(t *testing.T, f Factory) {
+	if f.GatewayPort == 0 {
+		t.Skip("no load balancer endpoint port configured")
+	}
+
+	ctx := f.context(t)
+	gridClient := f.Grid(t)
+	gateways := gridClient.Gateway()
+
+	// create
+	name := f.name("endpoint")
+	port := f.GatewayPort
+	secure := false
+	created, err := gateways.CreateGatewayConfig(ctx, &models.GatewayConfig{
+		DisplayName: &name,
+		Port:        &port,
+		Secure:      &secure,
+	})
+	if err != nil {
+		t.Fatalf("failed to create load balancer endpoint %s: %v", name, err)
+	}
+	if created.Id == "" {
+		t.Fatal("created load balancer endpoint has no id")
+	}
+	deleted := false
+	f.cleanup(t, "load balancer endpoint "+created.Id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return gateways.DeleteGatewayConfig(ctx, created.Id)
+	})
+
+	// get
+	gateway, err := gateways.GetGatewayConfigById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get load balancer endpoint %s: %v", created.Id, err)
+	}
+	if models.Deref(gateway.DisplayName) != name {
+		t.Errorf("load balancer endpoint name is %q, expected %q", models.Deref(gateway.DisplayName), name)
+	}
+	if gateway.Port == nil || *gateway.Port != port {
+		t.Errorf("load balancer endpoint port is %v, expected %d", gateway.Port, port)
+	}
+
+	_, err = gateways.GetGatewayServerConfig(ctx, created.Id)
+	if err != nil {
+		t.Errorf("failed to get server config of load balancer endpoint %s: %v", created.Id, err)
+	}
+
+	// update
+	renamed := f.name("endpoint-renamed")
+	gateway.DisplayName = &renamed
+	_, err = gateways.UpdateGatewayConfig(ctx, gateway)
+	if err != nil {
+		t.Fatalf("failed to update load balancer endpoint %s: %v", created.Id, err)
+	}
+
+	gateway, err = gateways.GetGatewayConfigById(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to get load balancer endpoint %s: %v", created.Id, err)
+	}
+	if models.Deref(gateway.DisplayName) != renamed {
+		t.Errorf("load balancer endpoint name is %q after update, expected %q", models.Deref(gateway.DisplayName), renamed)
+	}
+
+	// list
+	all, err := gateways.ListGatewayConfigs(ctx)
+	if err != nil {
+		t.Fatalf("failed to list load balancer endpoints: %v", err)
+	}
+	if !containsFunc(all, func(gateway models.GatewayConfig) bool { return gateway.Id == created.Id }) {
+		t.Errorf("load balancer endpoint %s is not listed", created.Id)
+	}
+
+	// delete
+	err = gateways.DeleteGatewayConfig(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("failed to delete load balancer endpoint %s: %v", created.Id, err)
+	}
+	deleted = true
+
+	_, err = gateways.GetGatewayConfigById(ctx, created.Id)
+	expectDeleted(t, "load balancer endpoint "+created.Id, err)
+}
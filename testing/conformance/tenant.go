@@ -0,0 +1,308 @@
+package conformance
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+func testBuckets(t *testing.T, f Factory, tenantClient TenantClient) {
+	ctx := f.context(t)
+	buckets := tenantClient.Bucket()
+
+	// create
+	name := f.name("bucket")
+	created, err := buckets.Create(ctx, &models.Bucket{Name: name, Region: f.region()})
+	if err != nil {
+		t.Fatalf("failed to create bucket %s: %v", name, err)
+	}
+	if created.Name != name {
+		t.Errorf("created bucket name is %q, expected %q", created.Name, name)
+	}
+	deleted := false
+	f.cleanup(t, "bucket "+name, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return buckets.Delete(ctx, name)
+	})
+
+	// get
+	bucket, err := buckets.GetByName(ctx, name)
+	if err != nil {
+		t.Fatalf("failed to get bucket %s: %v", name, err)
+	}
+	if bucket.Region != f.region() {
+		t.Errorf("bucket region is %q, expected %q", bucket.Region, f.region())
+	}
+
+	// list
+	all, err := buckets.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list buckets: %v", err)
+	}
+	if !containsFunc(all, func(bucket models.Bucket) bool { return bucket.Name == name }) {
+		t.Errorf("bucket %s is not listed", name)
+	}
+
+	// delete
+	err = buckets.Delete(ctx, name)
+	if err != nil {
+		t.Fatalf("failed to delete bucket %s: %v", name, err)
+	}
+	deleted = true
+
+	_, err = buckets.GetByName(ctx, name)
+	expectDeleted(t, "bucket "+name, err)
+}
+
+func testGroups(t *testing.T, f Factory, tenantClient TenantClient) {
+	ctx := f.context(t)
+	groups := tenantClient.Groups()
+
+	// create
+	uniqueName := "group/" + f.name("group")
+	created := createGroup(t, f, tenantClient, uniqueName)
+	id := *created.Id
+	deleted := false
+	f.cleanup(t, "group "+id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return groups.Delete(ctx, id)
+	})
+
+	// get
+	group, err := groups.GetById(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get group %s: %v", id, err)
+	}
+	if group.UniqueName != uniqueName {
+		t.Errorf("group unique name is %q, expected %q", group.UniqueName, uniqueName)
+	}
+
+	byName, err := groups.GetByName(ctx, uniqueName)
+	if err != nil {
+		t.Fatalf("failed to get group %s: %v", uniqueName, err)
+	}
+	if models.Deref(byName.Id) != id {
+		t.Errorf("group %s has id %q, expected %q", uniqueName, models.Deref(byName.Id), id)
+	}
+
+	// update
+	displayName := "Updated by the conformance suite"
+	group.DisplayName = displayName
+	_, err = groups.Update(ctx, group)
+	if err != nil {
+		t.Fatalf("failed to update group %s: %v", id, err)
+	}
+
+	group, err = groups.GetById(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get group %s: %v", id, err)
+	}
+	if group.DisplayName != displayName {
+		t.Errorf("group display name is %q after update, expected %q", group.DisplayName, displayName)
+	}
+
+	// list
+	all, err := groups.Iter().All(ctx)
+	if err != nil {
+		t.Fatalf("failed to list groups: %v", err)
+	}
+	if !containsFunc(all, func(group models.TenantGroup) bool { return models.Deref(group.Id) == id }) {
+		t.Errorf("group %s is not listed", id)
+	}
+
+	// delete
+	err = groups.Delete(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to delete group %s: %v", id, err)
+	}
+	deleted = true
+
+	_, err = groups.GetById(ctx, id)
+	expectDeleted(t, "group "+id, err)
+}
+
+func testUsers(t *testing.T, f Factory, tenantClient TenantClient) {
+	ctx := f.context(t)
+	users := tenantClient.Users()
+
+	group := createGroup(t, f, tenantClient, "group/"+f.name("user-group"))
+
+	// create
+	uniqueName := "user/" + f.name("user")
+	fullName := "Conformance User"
+	created := createUser(t, f, tenantClient, &models.User{
+		UniqueName: uniqueName,
+		FullName:   &fullName,
+		MemberOf:   []string{*group.Id},
+	})
+	id := *created.Id
+	deleted := false
+	f.cleanup(t, "user "+id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return users.Delete(ctx, id)
+	})
+
+	// get
+	user, err := users.GetById(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get user %s: %v", id, err)
+	}
+	if user.UniqueName != uniqueName {
+		t.Errorf("user unique name is %q, expected %q", user.UniqueName, uniqueName)
+	}
+	if !slices.Contains(user.MemberOf, *group.Id) {
+		t.Errorf("user %s is not a member of group %s", id, *group.Id)
+	}
+
+	byName, err := users.GetByName(ctx, uniqueName)
+	if err != nil {
+		t.Fatalf("failed to get user %s: %v", uniqueName, err)
+	}
+	if models.Deref(byName.Id) != id {
+		t.Errorf("user %s has id %q, expected %q", uniqueName, models.Deref(byName.Id), id)
+	}
+
+	// update
+	renamed := "Updated Conformance User"
+	user.FullName = &renamed
+	_, err = users.Update(ctx, user)
+	if err != nil {
+		t.Fatalf("failed to update user %s: %v", id, err)
+	}
+
+	user, err = users.GetById(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get user %s: %v", id, err)
+	}
+	if models.Deref(user.FullName) != renamed {
+		t.Errorf("user full name is %q after update, expected %q", models.Deref(user.FullName), renamed)
+	}
+
+	err = users.SetPassword(ctx, id, newPassword(t))
+	if err != nil {
+		t.Errorf("failed to set password of user %s: %v", id, err)
+	}
+
+	// list
+	all, err := users.Iter().All(ctx)
+	if err != nil {
+		t.Fatalf("failed to list users: %v", err)
+	}
+	if !containsFunc(all, func(user models.User) bool { return models.Deref(user.Id) == id }) {
+		t.Errorf("user %s is not listed", id)
+	}
+
+	// delete
+	err = users.Delete(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to delete user %s: %v", id, err)
+	}
+	deleted = true
+
+	_, err = users.GetById(ctx, id)
+	expectDeleted(t, "user "+id, err)
+}
+
+func testS3AccessKeys(t *testing.T, f Factory, tenantClient TenantClient) {
+	ctx := f.context(t)
+	keys := tenantClient.S3AccessKeys()
+
+	user := createUser(t, f, tenantClient, &models.User{UniqueName: "user/" + f.name("key-user")})
+	userId := *user.Id
+
+	// create
+	created, err := keys.CreateForUser(ctx, userId, &models.S3AccessKey{})
+	if err != nil {
+		t.Fatalf("failed to create access key for user %s: %v", userId, err)
+	}
+	if created.Id == nil || *created.Id == "" {
+		t.Fatal("created access key has no id")
+	}
+	if models.Deref(created.AccessKey) == "" || models.Deref(created.SecretAccessKey) == "" {
+		t.Error("created access key has no key pair")
+	}
+	id := *created.Id
+	deleted := false
+	f.cleanup(t, "access key "+id, func(ctx context.Context) error {
+		if deleted {
+			return nil
+		}
+		return keys.DeleteForUser(ctx, userId, id)
+	})
+
+	// get
+	key, err := keys.GetByIdForUser(ctx, userId, id)
+	if err != nil {
+		t.Fatalf("failed to get access key %s: %v", id, err)
+	}
+	if key.SecretAccessKey != nil {
+		t.Errorf("secret of access key %s is returned after creation", id)
+	}
+
+	// list
+	all, err := keys.ListForUser(ctx, userId)
+	if err != nil {
+		t.Fatalf("failed to list access keys of user %s: %v", userId, err)
+	}
+	if !containsFunc(all, func(key models.S3AccessKey) bool { return models.Deref(key.Id) == id }) {
+		t.Errorf("access key %s is not listed", id)
+	}
+
+	// delete
+	err = keys.DeleteForUser(ctx, userId, id)
+	if err != nil {
+		t.Fatalf("failed to delete access key %s: %v", id, err)
+	}
+	deleted = true
+
+	all, err = keys.ListForUser(ctx, userId)
+	if err != nil {
+		t.Fatalf("failed to list access keys of user %s: %v", userId, err)
+	}
+	if containsFunc(all, func(key models.S3AccessKey) bool { return models.Deref(key.Id) == id }) {
+		t.Errorf("access key %s is still listed after delete", id)
+	}
+}
+
+// createGroup creates a local read-only group. Groups the test does not delete are removed with the tenant account.
+func createGroup(t *testing.T, f Factory, tenantClient TenantClient, uniqueName string) *models.TenantGroup {
+	t.Helper()
+
+	readOnly := true
+	group, err := tenantClient.Groups().Create(f.context(t), &models.TenantGroup{
+		UniqueName:         uniqueName,
+		DisplayName:        "Conformance Group",
+		ManagementReadOnly: &readOnly,
+	})
+	if err != nil {
+		t.Fatalf("failed to create group %s: %v", uniqueName, err)
+	}
+	if group.Id == nil || *group.Id == "" {
+		t.Fatal("created group has no id")
+	}
+
+	return group
+}
+
+// createUser creates a local user. Users the test does not delete are removed with the tenant account.
+func createUser(t *testing.T, f Factory, tenantClient TenantClient, user *models.User) *models.User {
+	t.Helper()
+
+	created, err := tenantClient.Users().Create(f.context(t), user)
+	if err != nil {
+		t.Fatalf("failed to create user %s: %v", user.UniqueName, err)
+	}
+	if created.Id == nil || *created.Id == "" {
+		t.Fatal("created user has no id")
+	}
+
+	return created
+}
@@ -0,0 +1,426 @@
+package testing
+
+// Default behavior of the generated mocks when no XFunc field is set. The generator calls
+// defaultX for every method X defined here and returns zero values for all other methods.
+
+import (
+	"context"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+// MockBucketService
+
+func (m *MockBucketService) defaultList(ctx context.Context) (*[]models.Bucket, error) {
+	return &[]models.Bucket{}, nil
+}
+
+func (m *MockBucketService) defaultGetByName(ctx context.Context, name string) (*models.Bucket, error) {
+	return &models.Bucket{Name: name}, nil
+}
+
+func (m *MockBucketService) defaultCreate(ctx context.Context, bucket *models.Bucket) (*models.Bucket, error) {
+	return bucket, nil
+}
+
+func (m *MockBucketService) defaultGetUsage(ctx context.Context, name string) (*models.BucketStats, error) {
+	bucketName := name
+	return &models.BucketStats{Name: &bucketName}, nil
+}
+
+func (m *MockBucketService) defaultDrain(ctx context.Context, name string) (*models.BucketDeleteObjectStatus, error) {
+	return &models.BucketDeleteObjectStatus{}, nil
+}
+
+func (m *MockBucketService) defaultDrainStatus(ctx context.Context, name string) (*models.BucketDeleteObjectStatus, error) {
+	return &models.BucketDeleteObjectStatus{}, nil
+}
+
+// MockCurrentUserService
+
+func (m *MockCurrentUserService) defaultGet(ctx context.Context) (*models.User, error) {
+	mockId := "mock-user-id"
+	return &models.User{Id: &mockId, UniqueName: "user/mock-user"}, nil
+}
+
+func (m *MockCurrentUserService) defaultGetPermissions(ctx context.Context) (*models.Permissions, error) {
+	return &models.Permissions{}, nil
+}
+
+// MockEndpointService
+
+func (m *MockEndpointService) defaultList(ctx context.Context) (*[]models.Endpoint, error) {
+	return &[]models.Endpoint{}, nil
+}
+
+func (m *MockEndpointService) defaultGetById(ctx context.Context, id string) (*models.Endpoint, error) {
+	return &models.Endpoint{Id: id}, nil
+}
+
+func (m *MockEndpointService) defaultCreate(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	endpoint.Id = "mock-endpoint-id"
+	return endpoint, nil
+}
+
+func (m *MockEndpointService) defaultUpdate(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	return endpoint, nil
+}
+
+// MockGatewayConfigService
+
+func (m *MockGatewayConfigService) defaultListGatewayConfigs(ctx context.Context) (*[]models.GatewayConfig, error) {
+	return &[]models.GatewayConfig{}, nil
+}
+
+func (m *MockGatewayConfigService) defaultGetGatewayConfigById(ctx context.Context, id string) (*models.GatewayConfig, error) {
+	return &models.GatewayConfig{Id: id}, nil
+}
+
+func (m *MockGatewayConfigService) defaultCreateGatewayConfig(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error) {
+	gatewayConfig.Id = "mock-gateway-id"
+	return gatewayConfig, nil
+}
+
+func (m *MockGatewayConfigService) defaultUpdateGatewayConfig(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error) {
+	return gatewayConfig, nil
+}
+
+func (m *MockGatewayConfigService) defaultGetGatewayServerConfig(ctx context.Context, gatewayID string) (*models.GWServerConfig, error) {
+	return &models.GWServerConfig{}, nil
+}
+
+func (m *MockGatewayConfigService) defaultUpdateGatewayServerConfig(ctx context.Context, gatewayID string, gatewayServerConfig *models.GWServerConfig) (*models.GWServerConfig, error) {
+	return gatewayServerConfig, nil
+}
+
+// MockGridConfigService
+
+func (m *MockGridConfigService) defaultGetDNSServers(ctx context.Context) (*[]string, error) {
+	return &[]string{}, nil
+}
+
+func (m *MockGridConfigService) defaultUpdateDNSServers(ctx context.Context, servers []string) (*[]string, error) {
+	return &servers, nil
+}
+
+func (m *MockGridConfigService) defaultGetNTPServers(ctx context.Context) (*[]string, error) {
+	return &[]string{}, nil
+}
+
+func (m *MockGridConfigService) defaultUpdateNTPServers(ctx context.Context, servers []string) (*[]string, error) {
+	return &servers, nil
+}
+
+func (m *MockGridConfigService) defaultGetGridNetworks(ctx context.Context) (*[]string, error) {
+	return &[]string{}, nil
+}
+
+func (m *MockGridConfigService) defaultUpdateGridNetworks(ctx context.Context, subnets []string, passphrase string) (*[]string, error) {
+	return &subnets, nil
+}
+
+// MockGridRegionService
+
+func (m *MockGridRegionService) defaultList(ctx context.Context) (*[]string, error) {
+	return &[]string{"us-east-1", "us-west-2"}, nil
+}
+
+func (m *MockGridRegionService) defaultListDetailed(ctx context.Context) (*[]models.Region, error) {
+	return &[]models.Region{{Name: "us-east-1"}, {Name: "us-west-2"}}, nil
+}
+
+func (m *MockGridRegionService) defaultSet(ctx context.Context, regions []string) ([]string, error) {
+	return regions, nil
+}
+
+func (m *MockGridRegionService) defaultCreate(ctx context.Context, name string) ([]string, error) {
+	return []string{"us-east-1", "us-west-2", name}, nil
+}
+
+// MockGridS3AccessKeyService
+
+func (m *MockGridS3AccessKeyService) defaultListForTenant(ctx context.Context, accountId string) (*[]models.S3AccessKey, error) {
+	return &[]models.S3AccessKey{}, nil
+}
+
+func (m *MockGridS3AccessKeyService) defaultGetByIdForTenant(ctx context.Context, accountId string, id string) (*models.S3AccessKey, error) {
+	mockId := id
+	mockAccountId := accountId
+	return &models.S3AccessKey{Id: &mockId, AccountId: &mockAccountId}, nil
+}
+
+func (m *MockGridS3AccessKeyService) defaultCreateForTenant(ctx context.Context, accountId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	mockId := "mock-s3-key-id"
+	mockAccountId := accountId
+	s3AccessKey.Id = &mockId
+	s3AccessKey.AccountId = &mockAccountId
+	return s3AccessKey, nil
+}
+
+// MockHAGroupService
+
+func (m *MockHAGroupService) defaultList(ctx context.Context) (*[]models.HAGroup, error) {
+	return &[]models.HAGroup{}, nil
+}
+
+func (m *MockHAGroupService) defaultGetById(ctx context.Context, id string) (*models.HAGroup, error) {
+	return &models.HAGroup{Id: id}, nil
+}
+
+func (m *MockHAGroupService) defaultCreate(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error) {
+	hagroup.Id = "mock-hagroup-id"
+	return hagroup, nil
+}
+
+func (m *MockHAGroupService) defaultUpdate(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error) {
+	return hagroup, nil
+}
+
+// MockHealthService
+
+func (m *MockHealthService) defaultGet(ctx context.Context) (*models.Health, error) {
+	return &models.Health{}, nil
+}
+
+// MockNetworkSecurityService
+
+func (m *MockNetworkSecurityService) defaultListNodes(ctx context.Context) (*[]models.Node, error) {
+	return &[]models.Node{}, nil
+}
+
+func (m *MockNetworkSecurityService) defaultGetUntrustedClientNetwork(ctx context.Context) (*models.UntrustedClientNetwork, error) {
+	return &models.UntrustedClientNetwork{}, nil
+}
+
+func (m *MockNetworkSecurityService) defaultUpdateUntrustedClientNetwork(ctx context.Context, config *models.UntrustedClientNetwork) (*models.UntrustedClientNetwork, error) {
+	return config, nil
+}
+
+func (m *MockNetworkSecurityService) defaultSetNodeUntrusted(ctx context.Context, nodeId string, untrusted bool) (*models.UntrustedClientNetwork, error) {
+	return &models.UntrustedClientNetwork{
+		Nodes: []models.UntrustedClientNetworkNode{{NodeId: nodeId, Untrusted: untrusted}},
+	}, nil
+}
+
+func (m *MockNetworkSecurityService) defaultGetPrivilegedAddresses(ctx context.Context) (*models.FirewallPrivilegedAddresses, error) {
+	return &models.FirewallPrivilegedAddresses{}, nil
+}
+
+func (m *MockNetworkSecurityService) defaultUpdatePrivilegedAddresses(ctx context.Context, addresses *models.FirewallPrivilegedAddresses) (*models.FirewallPrivilegedAddresses, error) {
+	return addresses, nil
+}
+
+func (m *MockNetworkSecurityService) defaultGetExternalPorts(ctx context.Context) (*models.FirewallExternalPorts, error) {
+	return &models.FirewallExternalPorts{}, nil
+}
+
+func (m *MockNetworkSecurityService) defaultUpdateExternalPorts(ctx context.Context, ports *models.FirewallExternalPorts) (*models.FirewallExternalPorts, error) {
+	return ports, nil
+}
+
+// MockRegionService
+
+func (m *MockRegionService) defaultList(ctx context.Context) (*[]string, error) {
+	return &[]string{"us-east-1", "us-west-2"}, nil
+}
+
+// MockS3AccessKeyService
+
+func (m *MockS3AccessKeyService) defaultListForCurrentUser(ctx context.Context) (*[]models.S3AccessKey, error) {
+	return &[]models.S3AccessKey{}, nil
+}
+
+func (m *MockS3AccessKeyService) defaultListForUser(ctx context.Context, userId string) (*[]models.S3AccessKey, error) {
+	return &[]models.S3AccessKey{}, nil
+}
+
+func (m *MockS3AccessKeyService) defaultGetByIdForCurrentUser(ctx context.Context, id string) (*models.S3AccessKey, error) {
+	mockId := id
+	return &models.S3AccessKey{Id: &mockId}, nil
+}
+
+func (m *MockS3AccessKeyService) defaultGetByIdForUser(ctx context.Context, userId string, id string) (*models.S3AccessKey, error) {
+	mockId := id
+	return &models.S3AccessKey{Id: &mockId}, nil
+}
+
+func (m *MockS3AccessKeyService) defaultCreateForCurrentUser(ctx context.Context, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	mockId := "mock-s3-key-id"
+	s3AccessKey.Id = &mockId
+	return s3AccessKey, nil
+}
+
+func (m *MockS3AccessKeyService) defaultCreateForUser(ctx context.Context, userId string, s3AccessKey *models.S3AccessKey) (*models.S3AccessKey, error) {
+	mockId := "mock-s3-key-id"
+	s3AccessKey.Id = &mockId
+	return s3AccessKey, nil
+}
+
+func (m *MockS3AccessKeyService) defaultRotateForUser(ctx context.Context, userId string, opts *services.S3AccessKeyRotateOptions) (*services.S3AccessKeyRotation, error) {
+	mockId := "mock-s3-key-id"
+	return &services.S3AccessKeyRotation{Key: &models.S3AccessKey{Id: &mockId}}, nil
+}
+
+// MockTenantService
+
+func (m *MockTenantService) defaultList(ctx context.Context, opts ...services.ListOption) (*[]models.Tenant, error) {
+	return &[]models.Tenant{}, nil
+}
+
+func (m *MockTenantService) defaultIter(opts ...services.ListOption) *services.ListIter[models.Tenant] {
+	return services.NewListIter(m.List, func(tenant models.Tenant) string {
+		return tenant.Id
+	}, opts...)
+}
+
+func (m *MockTenantService) defaultGetById(ctx context.Context, id string) (*models.Tenant, error) {
+	return &models.Tenant{Id: id}, nil
+}
+
+func (m *MockTenantService) defaultCreate(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
+	tenant.Id = "mock-tenant-id"
+	return tenant, nil
+}
+
+func (m *MockTenantService) defaultUpdate(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
+	return tenant, nil
+}
+
+func (m *MockTenantService) defaultGetUsage(ctx context.Context, id string) (*models.TenantUsage, error) {
+	return &models.TenantUsage{}, nil
+}
+
+func (m *MockTenantService) defaultListDeleted(ctx context.Context) (*[]models.DeletedTenant, error) {
+	return &[]models.DeletedTenant{}, nil
+}
+
+func (m *MockTenantService) defaultGetRootUser(ctx context.Context, id string) (*models.User, error) {
+	accountId := id
+	return &models.User{UniqueName: models.TenantRootUsername, AccountId: &accountId}, nil
+}
+
+func (m *MockTenantService) defaultUpdateRootUser(ctx context.Context, id string, user *models.User) (*models.User, error) {
+	return user, nil
+}
+
+// MockTenantGroupService
+
+func (m *MockTenantGroupService) defaultList(ctx context.Context, opts ...services.ListOption) (*[]models.TenantGroup, error) {
+	return &[]models.TenantGroup{}, nil
+}
+
+func (m *MockTenantGroupService) defaultIter(opts ...services.ListOption) *services.ListIter[models.TenantGroup] {
+	return services.NewListIter(m.List, func(group models.TenantGroup) string {
+		if group.Id == nil {
+			return ""
+		}
+		return *group.Id
+	}, opts...)
+}
+
+func (m *MockTenantGroupService) defaultGetById(ctx context.Context, id string) (*models.TenantGroup, error) {
+	mockId := id
+	return &models.TenantGroup{Id: &mockId}, nil
+}
+
+func (m *MockTenantGroupService) defaultGetByName(ctx context.Context, name string) (*models.TenantGroup, error) {
+	mockId := "mock-group-id"
+	return &models.TenantGroup{Id: &mockId, UniqueName: name}, nil
+}
+
+func (m *MockTenantGroupService) defaultCreate(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
+	mockId := "mock-group-id"
+	group.Id = &mockId
+	return group, nil
+}
+
+func (m *MockTenantGroupService) defaultCreateFederated(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
+	mockId := "mock-group-id"
+	federated := true
+	group.Id = &mockId
+	group.Federated = &federated
+	return group, nil
+}
+
+func (m *MockTenantGroupService) defaultUpdate(ctx context.Context, group *models.TenantGroup) (*models.TenantGroup, error) {
+	return group, nil
+}
+
+func (m *MockTenantGroupService) defaultListMembers(ctx context.Context, groupId string) (*[]models.User, error) {
+	return &[]models.User{}, nil
+}
+
+func (m *MockTenantGroupService) defaultResolveIds(ctx context.Context, names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	for range names {
+		ids = append(ids, "mock-group-id")
+	}
+	return ids, nil
+}
+
+// MockTenantUserService
+
+func (m *MockTenantUserService) defaultList(ctx context.Context, opts ...services.ListOption) (*[]models.User, error) {
+	return &[]models.User{}, nil
+}
+
+func (m *MockTenantUserService) defaultIter(opts ...services.ListOption) *services.ListIter[models.User] {
+	return services.NewListIter(m.List, func(user models.User) string {
+		if user.Id == nil {
+			return ""
+		}
+		return *user.Id
+	}, opts...)
+}
+
+func (m *MockTenantUserService) defaultGetById(ctx context.Context, id string) (*models.User, error) {
+	mockId := id
+	return &models.User{Id: &mockId}, nil
+}
+
+func (m *MockTenantUserService) defaultGetByName(ctx context.Context, name string) (*models.User, error) {
+	mockId := "mock-user-id"
+	return &models.User{Id: &mockId, UniqueName: name}, nil
+}
+
+func (m *MockTenantUserService) defaultCreate(ctx context.Context, user *models.User) (*models.User, error) {
+	mockId := "mock-user-id"
+	user.Id = &mockId
+	return user, nil
+}
+
+func (m *MockTenantUserService) defaultCreateFederated(ctx context.Context, user *models.User) (*models.User, error) {
+	mockId := "mock-user-id"
+	federated := true
+	user.Id = &mockId
+	user.Federated = &federated
+	return user, nil
+}
+
+func (m *MockTenantUserService) defaultUpdate(ctx context.Context, user *models.User) (*models.User, error) {
+	return user, nil
+}
+
+// MockTrafficClassificationService
+
+func (m *MockTrafficClassificationService) defaultList(ctx context.Context) (*[]models.TrafficClassificationPolicy, error) {
+	return &[]models.TrafficClassificationPolicy{}, nil
+}
+
+func (m *MockTrafficClassificationService) defaultGetById(ctx context.Context, id string) (*models.TrafficClassificationPolicy, error) {
+	return &models.TrafficClassificationPolicy{Id: id}, nil
+}
+
+func (m *MockTrafficClassificationService) defaultCreate(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error) {
+	policy.Id = "mock-traffic-policy-id"
+	return policy, nil
+}
+
+func (m *MockTrafficClassificationService) defaultUpdate(ctx context.Context, policy *models.TrafficClassificationPolicy) (*models.TrafficClassificationPolicy, error) {
+	return policy, nil
+}
+
+func (m *MockTrafficClassificationService) defaultGetMetrics(ctx context.Context, id string) (*models.TrafficClassificationMetrics, error) {
+	return &models.TrafficClassificationMetrics{}, nil
+}
@@ -0,0 +1,82 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
+package testing
+
+import (
+	"context"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+	"github.com/yehlo/storagegrid-sdk-go/services"
+)
+
+// MockEndpointService implements services.EndpointServiceInterface for testing
+type MockEndpointService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.EndpointServiceInterface
+
+	ListFunc    func(ctx context.Context) (*[]models.Endpoint, error)
+	GetByIdFunc func(ctx context.Context, id string) (*models.Endpoint, error)
+	CreateFunc  func(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	UpdateFunc  func(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error)
+	DeleteFunc  func(ctx context.Context, id string) error
+}
+
+func (m *MockEndpointService) List(ctx context.Context) (*[]models.Endpoint, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.List(ctx)
+	}
+	return m.defaultList(ctx)
+}
+
+func (m *MockEndpointService) GetById(ctx context.Context, id string) (*models.Endpoint, error) {
+	m.record("GetById", id)
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(ctx, id)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.GetById(ctx, id)
+	}
+	return m.defaultGetById(ctx, id)
+}
+
+func (m *MockEndpointService) Create(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	m.record("Create", endpoint)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, endpoint)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.Create(ctx, endpoint)
+	}
+	return m.defaultCreate(ctx, endpoint)
+}
+
+func (m *MockEndpointService) Update(ctx context.Context, endpoint *models.Endpoint) (*models.Endpoint, error) {
+	m.record("Update", endpoint)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, endpoint)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.Update(ctx, endpoint)
+	}
+	return m.defaultUpdate(ctx, endpoint)
+}
+
+func (m *MockEndpointService) Delete(ctx context.Context, id string) error {
+	m.record("Delete", id)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.Delete(ctx, id)
+	}
+	return nil
+}
+
+// Compile-time interface compliance check
+var _ services.EndpointServiceInterface = (*MockEndpointService)(nil)
@@ -0,0 +1,451 @@
+package fakegrid
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+// gatewayConfig is a load balancer endpoint together with its server configuration
+type gatewayConfig struct {
+	config       models.GatewayConfig
+	serverConfig models.GWServerConfig
+}
+
+func (s *Server) serveGrid(w http.ResponseWriter, r *request) {
+	if _, ok := r.match("grid", "accounts"); ok {
+		s.serveAccounts(w, r)
+		return
+	}
+	if v, ok := r.match("grid", "accounts", "*"); ok {
+		s.serveAccount(w, r, v[0])
+		return
+	}
+	if v, ok := r.match("grid", "accounts", "*", "s3-access-keys"); ok {
+		if a := s.requireAccount(w, v[0]); a != nil {
+			s.serveKeys(w, r, a, a.rootUserId)
+		}
+		return
+	}
+	if v, ok := r.match("grid", "accounts", "*", "s3-access-keys", "*"); ok {
+		if a := s.requireAccount(w, v[0]); a != nil {
+			s.serveKey(w, r, a, a.rootUserId, v[1])
+		}
+		return
+	}
+	if v, ok := r.match("grid", "accounts", "*", "*"); ok {
+		s.serveAccountResource(w, r, v[0], v[1])
+		return
+	}
+	if _, ok := r.match("grid", "deleted-accounts"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, s.deleted)
+		return
+	}
+	if _, ok := r.match("grid", "health"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, s.health)
+		return
+	}
+	if _, ok := r.match("grid", "regions"); ok {
+		s.serveRegions(w, r)
+		return
+	}
+	if _, ok := r.match("grid", "users", "current-user"); ok && r.Method == http.MethodGet {
+		id := "00000000-0000-0000-0000-000000000000"
+		writeData(w, http.StatusOK, models.User{UniqueName: "user/" + s.gridUsername, Id: &id})
+		return
+	}
+	if _, ok := r.match("grid", "users", "current-user", "permissions"); ok && r.Method == http.MethodGet {
+		rootAccess := true
+		writeData(w, http.StatusOK, models.Permissions{RootAccess: &rootAccess})
+		return
+	}
+	if _, ok := r.match("private", "ha-groups"); ok {
+		s.serveHAGroups(w, r)
+		return
+	}
+	if v, ok := r.match("private", "ha-groups", "*"); ok {
+		s.serveHAGroup(w, r, v[0])
+		return
+	}
+	if _, ok := r.match("private", "gateway-configs"); ok {
+		s.serveGatewayConfigs(w, r)
+		return
+	}
+	if v, ok := r.match("private", "gateway-configs", "*"); ok {
+		s.serveGatewayConfig(w, r, v[0])
+		return
+	}
+	if v, ok := r.match("private", "gateway-configs", "*", "server-config"); ok {
+		s.serveGatewayServerConfig(w, r, v[0])
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "unknown endpoint %s", r.URL.Path)
+}
+
+func (s *Server) requireAccount(w http.ResponseWriter, id string) *account {
+	a, ok := s.accounts[id]
+	if !ok {
+		notFound(w, "account", id)
+		return nil
+	}
+
+	return a
+}
+
+func (s *Server) serveAccounts(w http.ResponseWriter, r *request) {
+	switch r.Method {
+	case http.MethodGet:
+		tenants := make([]models.Tenant, 0, len(s.accounts))
+		for _, a := range s.accounts {
+			tenants = append(tenants, a.tenant)
+		}
+		sort.Slice(tenants, func(i, j int) bool { return tenants[i].Id < tenants[j].Id })
+
+		page, err := paginate(r, tenants, func(t models.Tenant) string { return t.Id })
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		writeData(w, http.StatusOK, page)
+	case http.MethodPost:
+		tenant := models.Tenant{}
+		if !r.decode(w, &tenant) {
+			return
+		}
+		if tenant.Name == nil || *tenant.Name == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+		if len(tenant.Capabilities) == 0 {
+			writeError(w, http.StatusBadRequest, "capabilities are required")
+			return
+		}
+
+		password := ""
+		if tenant.Password != nil {
+			password = *tenant.Password
+		}
+		if password == "" && (tenant.Policy == nil || !tenant.Policy.UseAccountIdentitySource) {
+			writeError(w, http.StatusBadRequest, "password is required unless the account uses its own identity source")
+			return
+		}
+
+		a := s.newAccount(tenant, password)
+		writeData(w, http.StatusCreated, a.tenant)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveAccount(w http.ResponseWriter, r *request, id string) {
+	a := s.requireAccount(w, id)
+	if a == nil {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, a.tenant)
+	case http.MethodPut:
+		tenant := models.Tenant{}
+		if !r.decode(w, &tenant) {
+			return
+		}
+		if tenant.Name == nil || *tenant.Name == "" {
+			writeError(w, http.StatusBadRequest, "name is required")
+			return
+		}
+
+		tenant.Id = id
+		tenant.Password = nil
+		a.tenant = tenant
+		writeData(w, http.StatusOK, a.tenant)
+	case http.MethodDelete:
+		if len(a.buckets) > 0 {
+			writeError(w, http.StatusConflict, "account %s still has %d buckets", id, len(a.buckets))
+			return
+		}
+
+		now := time.Now().UTC()
+		state := "complete"
+		var zero int64
+		s.deleted = append(s.deleted, models.DeletedTenant{
+			Id:               id,
+			Name:             a.tenant.Name,
+			DeletionTime:     &now,
+			CleanupState:     &state,
+			RemainingObjects: &zero,
+			RemainingBytes:   &zero,
+		})
+		delete(s.accounts, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveAccountResource(w http.ResponseWriter, r *request, id string, resource string) {
+	a := s.requireAccount(w, id)
+	if a == nil {
+		return
+	}
+
+	root := a.users[a.rootUserId]
+
+	switch {
+	case resource == "usage" && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, a.usage())
+	case resource == "change-password" && r.Method == http.MethodPost:
+		data := map[string]string{}
+		if !r.decode(w, &data) {
+			return
+		}
+		if data["password"] == "" {
+			writeError(w, http.StatusBadRequest, "password is required")
+			return
+		}
+
+		root.password = data["password"]
+		writeNoContent(w)
+	case resource == "root-user" && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, root.User)
+	case resource == "root-user" && r.Method == http.MethodPut:
+		user := models.User{}
+		if !r.decode(w, &user) {
+			return
+		}
+
+		root.FullName = user.FullName
+		root.Disable = user.Disable
+		writeData(w, http.StatusOK, root.User)
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint %s", r.URL.Path)
+	}
+}
+
+func (s *Server) serveRegions(w http.ResponseWriter, r *request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, s.regions)
+	case http.MethodPut:
+		regions := []string{}
+		if !r.decode(w, &regions) {
+			return
+		}
+		if !slices.Contains(regions, models.DefaultRegion) {
+			writeError(w, http.StatusBadRequest, "region %s cannot be removed", models.DefaultRegion)
+			return
+		}
+		for _, a := range s.accounts {
+			for _, bucket := range a.buckets {
+				if !slices.Contains(regions, bucket.Region) {
+					writeError(w, http.StatusConflict, "region %s is used by bucket %s", bucket.Region, bucket.Name)
+					return
+				}
+			}
+		}
+
+		s.regions = regions
+		writeData(w, http.StatusOK, s.regions)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveHAGroups(w http.ResponseWriter, r *request) {
+	switch r.Method {
+	case http.MethodGet:
+		groups := make([]models.HAGroup, 0, len(s.haGroups))
+		for _, group := range s.haGroups {
+			groups = append(groups, *group)
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Id < groups[j].Id })
+		writeData(w, http.StatusOK, groups)
+	case http.MethodPost:
+		group := models.HAGroup{}
+		if !r.decode(w, &group) || !s.validateHAGroup(w, &group, "") {
+			return
+		}
+
+		group.Id = s.newUUID()
+		s.haGroups[group.Id] = &group
+		writeData(w, http.StatusCreated, group)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveHAGroup(w http.ResponseWriter, r *request, id string) {
+	existing, ok := s.haGroups[id]
+	if !ok {
+		notFound(w, "HA group", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, existing)
+	case http.MethodPut:
+		group := models.HAGroup{}
+		if !r.decode(w, &group) || !s.validateHAGroup(w, &group, id) {
+			return
+		}
+
+		group.Id = id
+		s.haGroups[id] = &group
+		writeData(w, http.StatusOK, group)
+	case http.MethodDelete:
+		for _, gateway := range s.gatewayConfigs {
+			pins := gateway.config.PinTargets
+			if pins != nil && pins.HaGroups != nil && slices.Contains(*pins.HaGroups, id) {
+				writeError(w, http.StatusConflict, "HA group %s is used by load balancer endpoint %s", id, gateway.config.Id)
+				return
+			}
+		}
+
+		delete(s.haGroups, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) validateHAGroup(w http.ResponseWriter, group *models.HAGroup, id string) bool {
+	if group.Name == nil || *group.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return false
+	}
+	if group.VirtualIps == nil || len(*group.VirtualIps) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one virtual IP is required")
+		return false
+	}
+	if group.Interfaces == nil || len(*group.Interfaces) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one interface is required")
+		return false
+	}
+
+	for _, other := range s.haGroups {
+		if other.Id != id && other.Name != nil && *other.Name == *group.Name {
+			writeError(w, http.StatusConflict, "HA group %s already exists", *group.Name)
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) serveGatewayConfigs(w http.ResponseWriter, r *request) {
+	switch r.Method {
+	case http.MethodGet:
+		configs := make([]models.GatewayConfig, 0, len(s.gatewayConfigs))
+		for _, gateway := range s.gatewayConfigs {
+			configs = append(configs, gateway.config)
+		}
+		sort.Slice(configs, func(i, j int) bool { return configs[i].Id < configs[j].Id })
+		writeData(w, http.StatusOK, configs)
+	case http.MethodPost:
+		config := models.GatewayConfig{}
+		if !r.decode(w, &config) || !s.validateGatewayConfig(w, &config, "") {
+			return
+		}
+
+		config.Id = s.newUUID()
+		serviceType := "s3"
+		restrictionMode := "none"
+		certSource := "default"
+		s.gatewayConfigs[config.Id] = &gatewayConfig{
+			config: config,
+			serverConfig: models.GWServerConfig{
+				DefaultServiceType:     &serviceType,
+				AccountRestrictionMode: &restrictionMode,
+				CertSource:             &certSource,
+			},
+		}
+		writeData(w, http.StatusCreated, config)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveGatewayConfig(w http.ResponseWriter, r *request, id string) {
+	existing, ok := s.gatewayConfigs[id]
+	if !ok {
+		notFound(w, "load balancer endpoint", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, existing.config)
+	case http.MethodPut:
+		config := models.GatewayConfig{}
+		if !r.decode(w, &config) || !s.validateGatewayConfig(w, &config, id) {
+			return
+		}
+
+		config.Id = id
+		existing.config = config
+		writeData(w, http.StatusOK, config)
+	case http.MethodDelete:
+		delete(s.gatewayConfigs, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) validateGatewayConfig(w http.ResponseWriter, config *models.GatewayConfig, id string) bool {
+	if config.DisplayName == nil || *config.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "displayName is required")
+		return false
+	}
+	if config.Port == nil || *config.Port < 1 || *config.Port > 65535 {
+		writeError(w, http.StatusBadRequest, "port must be between 1 and 65535")
+		return false
+	}
+
+	for _, other := range s.gatewayConfigs {
+		if other.config.Id != id && other.config.Port != nil && *other.config.Port == *config.Port {
+			writeError(w, http.StatusConflict, "port %d is already used by load balancer endpoint %s", *config.Port, other.config.Id)
+			return false
+		}
+	}
+
+	if config.PinTargets != nil && config.PinTargets.HaGroups != nil {
+		for _, groupId := range *config.PinTargets.HaGroups {
+			if _, ok := s.haGroups[groupId]; !ok {
+				writeError(w, http.StatusBadRequest, "HA group %s does not exist", groupId)
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *Server) serveGatewayServerConfig(w http.ResponseWriter, r *request, id string) {
+	existing, ok := s.gatewayConfigs[id]
+	if !ok {
+		notFound(w, "load balancer endpoint", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, existing.serverConfig)
+	case http.MethodPut:
+		config := models.GWServerConfig{}
+		if !r.decode(w, &config) {
+			return
+		}
+
+		existing.serverConfig = config
+		writeData(w, http.StatusOK, config)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
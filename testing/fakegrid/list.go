@@ -0,0 +1,50 @@
+package fakegrid
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// paginate applies the limit, marker, includeMarker and order query parameters to a list sorted by key
+func paginate[T any](r *request, items []T, key func(T) string) ([]T, error) {
+	query := r.URL.Query()
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	default:
+		return nil, fmt.Errorf("invalid order %q", query.Get("order"))
+	}
+
+	if marker := query.Get("marker"); marker != "" {
+		start := -1
+		for i, item := range items {
+			if key(item) == marker {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("marker %s not found", marker)
+		}
+		if query.Get("includeMarker") != "true" {
+			start++
+		}
+		items = items[start:]
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+		if n < len(items) {
+			items = items[:n]
+		}
+	}
+
+	return items, nil
+}
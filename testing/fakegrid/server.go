@@ -0,0 +1,512 @@
+// Package fakegrid provides an in-memory StorageGRID server for tests.
+//
+// The server speaks the api/v4 grid and tenant management protocol used by the client package,
+// including authorization with expiring tokens and the standard response envelope. State is kept
+// between requests, so services can be exercised end to end without a live grid:
+//
+//	grid := fakegrid.New()
+//	defer grid.Close()
+//
+//	gridClient, _ := client.NewGridClient(
+//		client.WithEndpoint(grid.URL),
+//		client.WithCredentials(grid.GridCredentials()),
+//	)
+package fakegrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+const (
+	apiPrefix  = "/api/v4"
+	apiVersion = "4.0"
+
+	// DefaultGridUsername is the grid administrator username unless WithGridCredentials is used
+	DefaultGridUsername = "root"
+	// DefaultGridPassword is the grid administrator password unless WithGridCredentials is used
+	DefaultGridPassword = "fakegrid"
+	// DefaultTokenLifetime is the validity of issued tokens unless WithTokenLifetime is used
+	DefaultTokenLifetime = time.Hour
+
+	expiresFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+)
+
+// Server is a stateful fake of the StorageGRID management API backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	gridUsername  string
+	gridPassword  string
+	tokenLifetime time.Duration
+	latency       time.Duration
+	faults        []*Fault
+	requests      []Request
+	sessions      map[string]*session
+	sequence      int
+
+	accounts       map[string]*account
+	deleted        []models.DeletedTenant
+	haGroups       map[string]*models.HAGroup
+	gatewayConfigs map[string]*gatewayConfig
+	regions        []string
+	health         models.Health
+}
+
+// session is an issued token. Grid administrator sessions have an empty accountId.
+type session struct {
+	accountId string
+	userId    string
+	expires   time.Time
+}
+
+// Fault makes matching requests fail before they reach the fake.
+type Fault struct {
+	// Method to match, empty matches any method
+	Method string
+	// Path pattern relative to /api/v4 in path.Match syntax, e.g. "/org/users/*"
+	Path string
+	// Status is the HTTP status code returned
+	Status int
+	// Message is the error text in the response body
+	Message string
+	// Times is the number of requests to fail. Zero fails all matching requests until ClearFaults is called.
+	Times int
+}
+
+// Request is a request received by the server, recorded for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+type Option func(*Server)
+
+func WithGridCredentials(username string, password string) Option {
+	return func(s *Server) {
+		s.gridUsername = username
+		s.gridPassword = password
+	}
+}
+
+func WithTokenLifetime(lifetime time.Duration) Option {
+	return func(s *Server) {
+		s.tokenLifetime = lifetime
+	}
+}
+
+func WithLatency(latency time.Duration) Option {
+	return func(s *Server) {
+		s.latency = latency
+	}
+}
+
+func WithHealth(health models.Health) Option {
+	return func(s *Server) {
+		s.health = health
+	}
+}
+
+// New starts a fake grid with a single default region and no tenants. Close it when done.
+func New(options ...Option) *Server {
+	zero := 0
+	s := &Server{
+		gridUsername:   DefaultGridUsername,
+		gridPassword:   DefaultGridPassword,
+		tokenLifetime:  DefaultTokenLifetime,
+		sessions:       map[string]*session{},
+		accounts:       map[string]*account{},
+		haGroups:       map[string]*models.HAGroup{},
+		gatewayConfigs: map[string]*gatewayConfig{},
+		regions:        []string{models.DefaultRegion},
+		health: models.Health{
+			Alarms: &models.Alarms{Critical: &zero, Major: &zero, Minor: &zero, Notice: &zero},
+			Alerts: &models.Alerts{Critical: &zero, Major: &zero, Minor: &zero},
+			Nodes:  &models.Nodes{Connected: &zero, AdministrativelyDown: &zero, Unknown: &zero},
+		},
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// GridCredentials returns credentials for a grid client.
+func (s *Server) GridCredentials() *models.Credentials {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &models.Credentials{Username: s.gridUsername, Password: s.gridPassword}
+}
+
+// TenantCredentials returns the root user credentials of a tenant account for a tenant client.
+func (s *Server) TenantCredentials(accountId string) (*models.Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[accountId]
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", accountId)
+	}
+
+	root := a.users[a.rootUserId]
+	return &models.Credentials{Username: models.TenantRootUsername, Password: root.password, AccountId: &accountId}, nil
+}
+
+// AddTenant creates a tenant account with a root user password, bypassing the API.
+func (s *Server) AddTenant(name string, rootPassword string) models.Tenant {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.newAccount(models.Tenant{Name: &name, Capabilities: []string{"management", "s3"}}, rootPassword)
+	return a.tenant
+}
+
+// SetIdentitySource configures the identity source of a tenant account, which enables federated users and groups.
+func (s *Server) SetIdentitySource(accountId string, identitySource models.IdentitySource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[accountId]
+	if !ok {
+		return fmt.Errorf("account %s not found", accountId)
+	}
+
+	a.identitySource = identitySource
+	return nil
+}
+
+func (s *Server) SetHealth(health models.Health) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.health = health
+}
+
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+}
+
+// InjectFault makes matching requests fail. Faults are evaluated in the order they were added.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.Status == 0 {
+		fault.Status = http.StatusInternalServerError
+	}
+	if fault.Message == "" {
+		fault.Message = "injected fault"
+	}
+
+	s.faults = append(s.faults, &fault)
+}
+
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// ExpireTokens invalidates all issued tokens, forcing clients to authorize again.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]*session{}
+}
+
+// Requests returns all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body: %v", err)
+		return
+	}
+
+	segments, err := splitPath(r.URL.EscapedPath())
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	apiPath := "/" + strings.Join(segments, "/")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: apiPath, Query: r.URL.Query(), Body: body})
+	latency := s.latency
+	fault := s.takeFault(r.Method, apiPath)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if fault != nil {
+		writeError(w, fault.Status, "%s", fault.Message)
+		return
+	}
+
+	req := &request{Request: r, body: body, segments: segments}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if apiPath == "/authorize" && r.Method == http.MethodPost {
+		s.authorize(w, req)
+		return
+	}
+
+	sess, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+	req.session = sess
+
+	switch segments[0] {
+	case "grid", "private":
+		if sess.accountId != "" {
+			writeError(w, http.StatusForbidden, "grid administrator access required")
+			return
+		}
+		s.serveGrid(w, req)
+	case "org":
+		if sess.accountId == "" {
+			writeError(w, http.StatusForbidden, "tenant access required")
+			return
+		}
+		s.serveTenant(w, req, s.accounts[sess.accountId])
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint %s", apiPath)
+	}
+}
+
+// request carries the decoded parts of an incoming request
+type request struct {
+	*http.Request
+	body     []byte
+	segments []string
+	session  *session
+}
+
+// match reports whether the request path matches the pattern segments, "*" matching any single segment.
+// The values of the wildcard segments are returned in order.
+func (r *request) match(pattern ...string) ([]string, bool) {
+	if len(pattern) != len(r.segments) {
+		return nil, false
+	}
+
+	values := []string{}
+	for i, p := range pattern {
+		if p == "*" {
+			values = append(values, r.segments[i])
+			continue
+		}
+		if p != r.segments[i] {
+			return nil, false
+		}
+	}
+
+	return values, true
+}
+
+// decode unmarshals the request body into v and writes a bad request response on failure
+func (r *request) decode(w http.ResponseWriter, v interface{}) bool {
+	err := json.Unmarshal(r.body, v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+
+	return true
+}
+
+func splitPath(escapedPath string) ([]string, error) {
+	if !strings.HasPrefix(escapedPath, apiPrefix+"/") {
+		return nil, fmt.Errorf("unknown endpoint %s", escapedPath)
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(escapedPath, apiPrefix), "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments[i] = unescaped
+	}
+
+	return segments, nil
+}
+
+// takeFault returns the first fault matching the request and consumes one of its occurrences
+func (s *Server) takeFault(method string, apiPath string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if ok, _ := path.Match(fault.Path, apiPath); !ok {
+			continue
+		}
+
+		matched := *fault
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *request) {
+	credentials := models.Credentials{}
+	if !r.decode(w, &credentials) {
+		return
+	}
+
+	sess := &session{expires: time.Now().Add(s.tokenLifetime)}
+	if credentials.AccountId == nil || *credentials.AccountId == "" || *credentials.AccountId == "0" {
+		if credentials.Username != s.gridUsername || credentials.Password != s.gridPassword {
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+	} else {
+		a, ok := s.accounts[*credentials.AccountId]
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+
+		u := a.login(credentials.Username, credentials.Password)
+		if u == nil {
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+
+		sess.accountId = *credentials.AccountId
+		sess.userId = *u.Id
+	}
+
+	token := s.newToken()
+	s.sessions[token] = sess
+
+	w.Header().Set("Expires", sess.expires.UTC().Format(expiresFormat))
+	writeData(w, http.StatusOK, token)
+}
+
+func (s *Server) authenticate(r *http.Request) (*session, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return nil, false
+	}
+
+	sess, ok := s.sessions[token]
+	if !ok || time.Now().After(sess.expires) {
+		return nil, false
+	}
+
+	if sess.accountId != "" {
+		a, ok := s.accounts[sess.accountId]
+		if !ok {
+			return nil, false
+		}
+		u, ok := a.users[sess.userId]
+		if !ok || (u.Disable != nil && *u.Disable) {
+			return nil, false
+		}
+	}
+
+	return sess, true
+}
+
+func (s *Server) newToken() string {
+	return randomHex(16)
+}
+
+// newAccountId returns a 20 digit account ID like the ones issued by StorageGRID
+func (s *Server) newAccountId() string {
+	s.sequence++
+	return fmt.Sprintf("%020d", 10000000000000000000+uint64(s.sequence))
+}
+
+// newUUID returns a unique, ordered UUID
+func (s *Server) newUUID() string {
+	s.sequence++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.sequence)
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	now := time.Now().UTC()
+	writeJSON(w, status, models.Response{ResponseTime: &now, Status: "success", ApiVersion: apiVersion, Data: data})
+}
+
+// errorResponse is the envelope StorageGRID uses for failed requests
+type errorResponse struct {
+	ResponseTime time.Time `json:"responseTime"`
+	Status       string    `json:"status"`
+	ApiVersion   string    `json:"apiVersion"`
+	Code         int       `json:"code"`
+	Message      struct {
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	response := errorResponse{ResponseTime: time.Now().UTC(), Status: "error", ApiVersion: apiVersion, Code: status}
+	response.Message.Text = fmt.Sprintf(format, args...)
+	writeJSON(w, status, response)
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *request) {
+	writeError(w, http.StatusMethodNotAllowed, "method %s not allowed on %s", r.Method, r.URL.Path)
+}
+
+func notFound(w http.ResponseWriter, kind string, id string) {
+	writeError(w, http.StatusNotFound, "%s %s not found", kind, id)
+}
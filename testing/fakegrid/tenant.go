@@ -0,0 +1,778 @@
+package fakegrid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yehlo/storagegrid-sdk-go/models"
+)
+
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// account is the state of a tenant account
+type account struct {
+	tenant         models.Tenant
+	rootUserId     string
+	users          map[string]*user
+	groups         map[string]*models.TenantGroup
+	buckets        map[string]*models.Bucket
+	keys           map[string]*accessKey
+	endpoints      map[string]*models.Endpoint
+	identitySource models.IdentitySource
+}
+
+type user struct {
+	models.User
+	password string
+}
+
+type accessKey struct {
+	key    models.S3AccessKey
+	userId string
+}
+
+func (s *Server) newAccount(tenant models.Tenant, rootPassword string) *account {
+	tenant.Id = s.newAccountId()
+	tenant.Password = nil
+
+	rootId := s.newUUID()
+	fullName := "Root"
+	urn := "urn:sgws:identity::" + tenant.Id + ":root"
+	a := &account{
+		tenant:     tenant,
+		rootUserId: rootId,
+		users: map[string]*user{
+			rootId: {
+				User:     models.User{UniqueName: models.TenantRootUsername, FullName: &fullName, AccountId: &tenant.Id, Id: &rootId, UserURN: &urn},
+				password: rootPassword,
+			},
+		},
+		groups:    map[string]*models.TenantGroup{},
+		buckets:   map[string]*models.Bucket{},
+		keys:      map[string]*accessKey{},
+		endpoints: map[string]*models.Endpoint{},
+	}
+	s.accounts[tenant.Id] = a
+
+	return a
+}
+
+// login returns the enabled user matching the credentials
+func (a *account) login(username string, password string) *user {
+	uniqueName := "user/" + username
+	if username == models.TenantRootUsername {
+		uniqueName = models.TenantRootUsername
+	}
+
+	for _, u := range a.users {
+		if u.UniqueName == uniqueName && u.password != "" && u.password == password {
+			if u.Disable != nil && *u.Disable {
+				return nil
+			}
+			return u
+		}
+	}
+
+	return nil
+}
+
+func (a *account) usage() models.TenantUsage {
+	now := time.Now().UTC()
+	var zero int64
+	usage := models.TenantUsage{CalculationTime: &now, ObjectCount: &zero, DataBytes: &zero, Buckets: []*models.BucketStats{}}
+	for _, bucket := range a.sortedBuckets() {
+		usage.Buckets = append(usage.Buckets, bucketStats(bucket))
+	}
+
+	return usage
+}
+
+func (a *account) sortedBuckets() []models.Bucket {
+	buckets := make([]models.Bucket, 0, len(a.buckets))
+	for _, bucket := range a.buckets {
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+
+	return buckets
+}
+
+func bucketStats(bucket models.Bucket) *models.BucketStats {
+	name := bucket.Name
+	region := bucket.Region
+	objects := 0
+	var bytes int64
+	consistency := "read-after-new-write"
+	versioning := bucket.EnableVersioning != nil && *bucket.EnableVersioning
+	suspended := false
+
+	return &models.BucketStats{
+		Name:                &name,
+		ObjectCount:         &objects,
+		DataBytes:           &bytes,
+		Consistency:         &consistency,
+		VersioningEnabled:   &versioning,
+		VersioningSuspended: &suspended,
+		Region:              &region,
+	}
+}
+
+func (s *Server) serveTenant(w http.ResponseWriter, r *request, a *account) {
+	if _, ok := r.match("org", "containers"); ok {
+		s.serveBuckets(w, r, a)
+		return
+	}
+	if v, ok := r.match("org", "containers", "*"); ok {
+		s.serveBucket(w, r, a, v[0])
+		return
+	}
+	if v, ok := r.match("org", "containers", "*", "delete-objects"); ok {
+		s.serveBucketDrain(w, r, a, v[0])
+		return
+	}
+	if _, ok := r.match("org", "usage"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, a.usage())
+		return
+	}
+	if _, ok := r.match("org", "regions"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, s.regions)
+		return
+	}
+	if _, ok := r.match("org", "identity-source"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, a.identitySource)
+		return
+	}
+	if _, ok := r.match("org", "users", "current-user"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, a.users[r.session.userId].User)
+		return
+	}
+	if _, ok := r.match("org", "users", "current-user", "permissions"); ok && r.Method == http.MethodGet {
+		writeData(w, http.StatusOK, a.permissions(r.session.userId))
+		return
+	}
+	if _, ok := r.match("org", "users", "current-user", "change-password"); ok && r.Method == http.MethodPost {
+		data := map[string]string{}
+		if !r.decode(w, &data) {
+			return
+		}
+
+		u := a.users[r.session.userId]
+		if data["currentPassword"] != u.password {
+			writeError(w, http.StatusBadRequest, "current password is incorrect")
+			return
+		}
+		if data["password"] == "" {
+			writeError(w, http.StatusBadRequest, "password is required")
+			return
+		}
+
+		u.password = data["password"]
+		writeNoContent(w)
+		return
+	}
+	if _, ok := r.match("org", "users", "current-user", "s3-access-keys"); ok {
+		s.serveKeys(w, r, a, r.session.userId)
+		return
+	}
+	if v, ok := r.match("org", "users", "current-user", "s3-access-keys", "*"); ok {
+		s.serveKey(w, r, a, r.session.userId, v[0])
+		return
+	}
+	if _, ok := r.match("org", "users"); ok {
+		s.serveUsers(w, r, a)
+		return
+	}
+	if v, ok := r.match("org", "users", "*", "*"); ok && (v[0] == "user" || v[0] == "federated-user") {
+		s.serveUserByName(w, r, a, v[0]+"/"+v[1])
+		return
+	}
+	if v, ok := r.match("org", "users", "*"); ok {
+		s.serveUser(w, r, a, v[0])
+		return
+	}
+	if v, ok := r.match("org", "users", "*", "change-password"); ok && r.Method == http.MethodPost {
+		s.serveUserPassword(w, r, a, v[0])
+		return
+	}
+	if v, ok := r.match("org", "users", "*", "s3-access-keys"); ok {
+		if _, exists := a.users[v[0]]; !exists {
+			notFound(w, "user", v[0])
+			return
+		}
+		s.serveKeys(w, r, a, v[0])
+		return
+	}
+	if v, ok := r.match("org", "users", "*", "s3-access-keys", "*"); ok {
+		if _, exists := a.users[v[0]]; !exists {
+			notFound(w, "user", v[0])
+			return
+		}
+		s.serveKey(w, r, a, v[0], v[1])
+		return
+	}
+	if _, ok := r.match("org", "endpoints"); ok {
+		s.serveEndpoints(w, r, a)
+		return
+	}
+	if v, ok := r.match("org", "endpoints", "*"); ok {
+		s.serveEndpoint(w, r, a, v[0])
+		return
+	}
+	if _, ok := r.match("org", "groups"); ok {
+		s.serveGroups(w, r, a)
+		return
+	}
+	if v, ok := r.match("org", "groups", "*", "*"); ok && (v[0] == "group" || v[0] == "federated-group") {
+		s.serveGroupByName(w, r, a, v[0]+"/"+v[1])
+		return
+	}
+	if v, ok := r.match("org", "groups", "*"); ok {
+		s.serveGroup(w, r, a, v[0])
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "unknown endpoint %s", r.URL.Path)
+}
+
+// permissions merges the management policies of all groups of the user
+func (a *account) permissions(userId string) models.Permissions {
+	yes := true
+	if userId == a.rootUserId {
+		return models.Permissions{RootAccess: &yes}
+	}
+
+	permissions := models.Permissions{}
+	for _, groupId := range a.users[userId].MemberOf {
+		group, ok := a.groups[groupId]
+		if !ok || group.Policies == nil || group.Policies.Management == nil {
+			continue
+		}
+
+		management := group.Policies.Management
+		for _, p := range []struct {
+			granted *bool
+			target  **bool
+		}{
+			{management.RootAccess, &permissions.RootAccess},
+			{management.ManageAllContainers, &permissions.ManageAllContainers},
+			{management.ManageEndpoints, &permissions.ManageEndpoints},
+			{management.ManageOwnS3Credentials, &permissions.ManageOwnS3Credentials},
+			{management.ManageOwnContainerObjects, &permissions.ManageOwnContainerObjects},
+			{management.ViewAllContainers, &permissions.ViewAllContainers},
+		} {
+			if p.granted != nil && *p.granted {
+				*p.target = &yes
+			}
+		}
+	}
+
+	return permissions
+}
+
+func (s *Server) serveBuckets(w http.ResponseWriter, r *request, a *account) {
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, a.sortedBuckets())
+	case http.MethodPost:
+		bucket := models.Bucket{}
+		if !r.decode(w, &bucket) {
+			return
+		}
+		if !bucketNamePattern.MatchString(bucket.Name) {
+			writeError(w, http.StatusBadRequest, "invalid bucket name %q", bucket.Name)
+			return
+		}
+		if bucket.Region == "" {
+			bucket.Region = models.DefaultRegion
+		}
+		if !slices.Contains(s.regions, bucket.Region) {
+			writeError(w, http.StatusBadRequest, "region %s does not exist", bucket.Region)
+			return
+		}
+		// bucket names are unique across the grid
+		for _, other := range s.accounts {
+			if _, exists := other.buckets[bucket.Name]; exists {
+				writeError(w, http.StatusConflict, "bucket %s already exists", bucket.Name)
+				return
+			}
+		}
+
+		bucket.CreationTime = time.Now().UTC().Truncate(time.Second)
+		a.buckets[bucket.Name] = &bucket
+		writeData(w, http.StatusCreated, bucket)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *request, a *account, name string) {
+	if _, ok := a.buckets[name]; !ok {
+		notFound(w, "bucket", name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		delete(a.buckets, name)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveBucketDrain(w http.ResponseWriter, r *request, a *account, name string) {
+	bucket, ok := a.buckets[name]
+	if !ok {
+		notFound(w, "bucket", name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if bucket.DeleteObjectStatus == nil {
+			deleting := false
+			bucket.DeleteObjectStatus = &models.BucketDeleteObjectStatus{IsDeletingObjects: &deleting}
+		}
+		writeData(w, http.StatusOK, bucket.DeleteObjectStatus)
+	case http.MethodPost:
+		data := map[string]string{}
+		if !r.decode(w, &data) {
+			return
+		}
+
+		// the fake holds no objects, so draining finishes immediately
+		deleting := data["deleteObjects"] == "true"
+		var count int32
+		var bytes int64
+		bucket.DeleteObjectStatus = &models.BucketDeleteObjectStatus{IsDeletingObjects: &deleting, InitialObjectCount: &count, InitialObjectBytes: &bytes}
+		writeData(w, http.StatusOK, bucket.DeleteObjectStatus)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *request, a *account) {
+	switch r.Method {
+	case http.MethodGet:
+		users := []models.User{}
+		for _, u := range a.users {
+			if matchesType(r, u.Federated) {
+				users = append(users, u.User)
+			}
+		}
+		sort.Slice(users, func(i, j int) bool { return *users[i].Id < *users[j].Id })
+
+		page, err := paginate(r, users, func(u models.User) string { return *u.Id })
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		writeData(w, http.StatusOK, page)
+	case http.MethodPost:
+		u := models.User{}
+		if !r.decode(w, &u) {
+			return
+		}
+
+		federated, ok := a.validateUniqueName(w, u.UniqueName, "user")
+		if !ok || !a.validateMemberOf(w, u.MemberOf) {
+			return
+		}
+		for _, other := range a.users {
+			if other.UniqueName == u.UniqueName {
+				writeError(w, http.StatusConflict, "user %s already exists", u.UniqueName)
+				return
+			}
+		}
+
+		id := s.newUUID()
+		urn := "urn:sgws:identity::" + a.tenant.Id + ":" + u.UniqueName
+		u.Id = &id
+		u.AccountId = &a.tenant.Id
+		u.Federated = &federated
+		u.UserURN = &urn
+		a.users[id] = &user{User: u}
+		writeData(w, http.StatusCreated, u)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveUserByName(w http.ResponseWriter, r *request, a *account, uniqueName string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+
+	for _, u := range a.users {
+		if u.UniqueName == uniqueName {
+			writeData(w, http.StatusOK, u.User)
+			return
+		}
+	}
+
+	notFound(w, "user", uniqueName)
+}
+
+func (s *Server) serveUser(w http.ResponseWriter, r *request, a *account, id string) {
+	existing, ok := a.users[id]
+	if !ok {
+		notFound(w, "user", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, existing.User)
+	case http.MethodPut, http.MethodPatch:
+		u := models.User{}
+		if !r.decode(w, &u) || !a.validateMemberOf(w, u.MemberOf) {
+			return
+		}
+
+		existing.FullName = u.FullName
+		existing.MemberOf = u.MemberOf
+		existing.Disable = u.Disable
+		writeData(w, http.StatusOK, existing.User)
+	case http.MethodDelete:
+		if id == a.rootUserId {
+			writeError(w, http.StatusBadRequest, "the root user cannot be deleted")
+			return
+		}
+
+		for keyId, key := range a.keys {
+			if key.userId == id {
+				delete(a.keys, keyId)
+			}
+		}
+		delete(a.users, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveUserPassword(w http.ResponseWriter, r *request, a *account, id string) {
+	existing, ok := a.users[id]
+	if !ok {
+		notFound(w, "user", id)
+		return
+	}
+
+	data := map[string]string{}
+	if !r.decode(w, &data) {
+		return
+	}
+	if existing.Federated != nil && *existing.Federated {
+		writeError(w, http.StatusBadRequest, "federated users have no local password")
+		return
+	}
+	if data["password"] == "" {
+		writeError(w, http.StatusBadRequest, "password is required")
+		return
+	}
+
+	existing.password = data["password"]
+	writeNoContent(w)
+}
+
+func (s *Server) serveGroups(w http.ResponseWriter, r *request, a *account) {
+	switch r.Method {
+	case http.MethodGet:
+		groups := []models.TenantGroup{}
+		for _, group := range a.groups {
+			if matchesType(r, group.Federated) {
+				groups = append(groups, *group)
+			}
+		}
+		sort.Slice(groups, func(i, j int) bool { return *groups[i].Id < *groups[j].Id })
+
+		page, err := paginate(r, groups, func(g models.TenantGroup) string { return *g.Id })
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		writeData(w, http.StatusOK, page)
+	case http.MethodPost:
+		group := models.TenantGroup{}
+		if !r.decode(w, &group) {
+			return
+		}
+
+		federated, ok := a.validateUniqueName(w, group.UniqueName, "group")
+		if !ok {
+			return
+		}
+		for _, other := range a.groups {
+			if other.UniqueName == group.UniqueName {
+				writeError(w, http.StatusConflict, "group %s already exists", group.UniqueName)
+				return
+			}
+		}
+
+		id := s.newUUID()
+		urn := "urn:sgws:identity::" + a.tenant.Id + ":" + group.UniqueName
+		group.Id = &id
+		group.AccountId = &a.tenant.Id
+		group.Federated = &federated
+		group.GroupURN = &urn
+		a.groups[id] = &group
+		writeData(w, http.StatusCreated, group)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveGroupByName(w http.ResponseWriter, r *request, a *account, uniqueName string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+
+	for _, group := range a.groups {
+		if group.UniqueName == uniqueName {
+			writeData(w, http.StatusOK, group)
+			return
+		}
+	}
+
+	notFound(w, "group", uniqueName)
+}
+
+func (s *Server) serveGroup(w http.ResponseWriter, r *request, a *account, id string) {
+	existing, ok := a.groups[id]
+	if !ok {
+		notFound(w, "group", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, existing)
+	case http.MethodPut, http.MethodPatch:
+		group := models.TenantGroup{}
+		if !r.decode(w, &group) {
+			return
+		}
+
+		existing.DisplayName = group.DisplayName
+		existing.ManagementReadOnly = group.ManagementReadOnly
+		existing.Policies = group.Policies
+		writeData(w, http.StatusOK, existing)
+	case http.MethodDelete:
+		for _, u := range a.users {
+			u.MemberOf = slices.DeleteFunc(u.MemberOf, func(groupId string) bool { return groupId == id })
+		}
+		delete(a.groups, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// validateUniqueName checks the "<kind>/" or "federated-<kind>/" prefix and returns whether the name is federated
+func (a *account) validateUniqueName(w http.ResponseWriter, uniqueName string, kind string) (bool, bool) {
+	if name, ok := strings.CutPrefix(uniqueName, kind+"/"); ok && name != "" {
+		return false, true
+	}
+
+	if name, ok := strings.CutPrefix(uniqueName, "federated-"+kind+"/"); ok && name != "" {
+		if !a.identitySource.Enabled() {
+			writeError(w, http.StatusBadRequest, "federated %ss require an identity source", kind)
+			return true, false
+		}
+		return true, true
+	}
+
+	writeError(w, http.StatusBadRequest, "uniqueName must start with %s/ or federated-%s/", kind, kind)
+	return false, false
+}
+
+func (a *account) validateMemberOf(w http.ResponseWriter, groupIds []string) bool {
+	for _, groupId := range groupIds {
+		if _, ok := a.groups[groupId]; !ok {
+			writeError(w, http.StatusBadRequest, "group %s does not exist", groupId)
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesType applies the "type" filter of user and group lists
+func matchesType(r *request, federated *bool) bool {
+	switch r.URL.Query().Get("type") {
+	case "":
+		return true
+	case "local":
+		return federated == nil || !*federated
+	case "federated":
+		return federated != nil && *federated
+	default:
+		return false
+	}
+}
+
+func (s *Server) serveKeys(w http.ResponseWriter, r *request, a *account, userId string) {
+	switch r.Method {
+	case http.MethodGet:
+		keys := []models.S3AccessKey{}
+		for _, key := range a.keys {
+			if key.userId == userId {
+				listed := key.key
+				listed.SecretAccessKey = nil
+				keys = append(keys, listed)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return *keys[i].Id < *keys[j].Id })
+		writeData(w, http.StatusOK, keys)
+	case http.MethodPost:
+		key := models.S3AccessKey{}
+		if len(r.body) > 0 && !r.decode(w, &key) {
+			return
+		}
+		if key.Expires != nil && key.Expires.Before(time.Now()) {
+			writeError(w, http.StatusBadRequest, "expires must be in the future")
+			return
+		}
+
+		if key.AccessKey == nil {
+			accessKey := "SG" + strings.ToUpper(randomHex(9))
+			secret := randomHex(20)
+			key.AccessKey = &accessKey
+			key.SecretAccessKey = &secret
+		} else if _, exists := a.keys[*key.AccessKey]; exists {
+			writeError(w, http.StatusConflict, "access key %s already exists", *key.AccessKey)
+			return
+		}
+
+		owner := a.users[userId]
+		id := *key.AccessKey
+		key.Id = &id
+		key.AccountId = &a.tenant.Id
+		key.UserURN = owner.UserURN
+		key.UserUUID = owner.Id
+		key.DisplayName = &id
+		a.keys[id] = &accessKey{key: key, userId: userId}
+		writeData(w, http.StatusCreated, key)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveKey(w http.ResponseWriter, r *request, a *account, userId string, id string) {
+	key, ok := a.keys[id]
+	if !ok || key.userId != userId {
+		notFound(w, "access key", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		listed := key.key
+		listed.SecretAccessKey = nil
+		writeData(w, http.StatusOK, listed)
+	case http.MethodDelete:
+		delete(a.keys, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func (s *Server) serveEndpoints(w http.ResponseWriter, r *request, a *account) {
+	switch r.Method {
+	case http.MethodGet:
+		endpoints := make([]models.Endpoint, 0, len(a.endpoints))
+		for _, endpoint := range a.endpoints {
+			endpoints = append(endpoints, withoutSecrets(*endpoint))
+		}
+		sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Id < endpoints[j].Id })
+		writeData(w, http.StatusOK, endpoints)
+	case http.MethodPost:
+		endpoint := models.Endpoint{}
+		if !r.decode(w, &endpoint) || !a.validateEndpoint(w, &endpoint, "") {
+			return
+		}
+
+		endpoint.Id = s.newUUID()
+		endpoint.Error = nil
+		a.endpoints[endpoint.Id] = &endpoint
+		writeData(w, http.StatusCreated, withoutSecrets(endpoint))
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveEndpoint(w http.ResponseWriter, r *request, a *account, id string) {
+	existing, ok := a.endpoints[id]
+	if !ok {
+		notFound(w, "endpoint", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, withoutSecrets(*existing))
+	case http.MethodPut:
+		endpoint := models.Endpoint{}
+		if !r.decode(w, &endpoint) || !a.validateEndpoint(w, &endpoint, id) {
+			return
+		}
+
+		endpoint.Id = id
+		endpoint.Error = nil
+		a.endpoints[id] = &endpoint
+		writeData(w, http.StatusOK, withoutSecrets(endpoint))
+	case http.MethodDelete:
+		delete(a.endpoints, id)
+		writeNoContent(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// validateEndpoint checks the required fields, the uniqueness of the URN and that the account may use platform services
+func (a *account) validateEndpoint(w http.ResponseWriter, endpoint *models.Endpoint, id string) bool {
+	if a.tenant.Policy == nil || !a.tenant.Policy.AllowPlatformServices {
+		writeError(w, http.StatusForbidden, "platform services are not allowed for this account")
+		return false
+	}
+	if endpoint.DisplayName == "" || endpoint.EndpointURI == "" || endpoint.EndpointURN == "" {
+		writeError(w, http.StatusBadRequest, "displayName, endpointURI and endpointURN are required")
+		return false
+	}
+
+	for _, other := range a.endpoints {
+		if other.Id != id && other.EndpointURN == endpoint.EndpointURN {
+			writeError(w, http.StatusConflict, "endpoint %s already exists", endpoint.EndpointURN)
+			return false
+		}
+	}
+
+	return true
+}
+
+// withoutSecrets removes the write-only secrets of the endpoint credentials
+func withoutSecrets(endpoint models.Endpoint) models.Endpoint {
+	if endpoint.Credentials != nil {
+		credentials := *endpoint.Credentials
+		credentials.SecretAccessKey = nil
+		credentials.Password = nil
+		endpoint.Credentials = &credentials
+	}
+
+	return endpoint
+}
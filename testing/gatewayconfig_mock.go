@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -9,6 +11,11 @@ import (
 
 // MockGatewayConfigService implements services.GatewayConfigServiceInterface for testing
 type MockGatewayConfigService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.GatewayConfigServiceInterface
+
 	ListGatewayConfigsFunc        func(ctx context.Context) (*[]models.GatewayConfig, error)
 	GetGatewayConfigByIdFunc      func(ctx context.Context, id string) (*models.GatewayConfig, error)
 	CreateGatewayConfigFunc       func(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error)
@@ -19,53 +26,80 @@ type MockGatewayConfigService struct {
 }
 
 func (m *MockGatewayConfigService) ListGatewayConfigs(ctx context.Context) (*[]models.GatewayConfig, error) {
+	m.record("ListGatewayConfigs")
 	if m.ListGatewayConfigsFunc != nil {
 		return m.ListGatewayConfigsFunc(ctx)
 	}
-	return &[]models.GatewayConfig{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.ListGatewayConfigs(ctx)
+	}
+	return m.defaultListGatewayConfigs(ctx)
 }
 
 func (m *MockGatewayConfigService) GetGatewayConfigById(ctx context.Context, id string) (*models.GatewayConfig, error) {
+	m.record("GetGatewayConfigById", id)
 	if m.GetGatewayConfigByIdFunc != nil {
 		return m.GetGatewayConfigByIdFunc(ctx, id)
 	}
-	return &models.GatewayConfig{Id: id}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetGatewayConfigById(ctx, id)
+	}
+	return m.defaultGetGatewayConfigById(ctx, id)
 }
 
 func (m *MockGatewayConfigService) CreateGatewayConfig(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error) {
+	m.record("CreateGatewayConfig", gatewayConfig)
 	if m.CreateGatewayConfigFunc != nil {
 		return m.CreateGatewayConfigFunc(ctx, gatewayConfig)
 	}
-	gatewayConfig.Id = "mock-gateway-id"
-	return gatewayConfig, nil
+	if m.Delegate != nil {
+		return m.Delegate.CreateGatewayConfig(ctx, gatewayConfig)
+	}
+	return m.defaultCreateGatewayConfig(ctx, gatewayConfig)
 }
 
 func (m *MockGatewayConfigService) UpdateGatewayConfig(ctx context.Context, gatewayConfig *models.GatewayConfig) (*models.GatewayConfig, error) {
+	m.record("UpdateGatewayConfig", gatewayConfig)
 	if m.UpdateGatewayConfigFunc != nil {
 		return m.UpdateGatewayConfigFunc(ctx, gatewayConfig)
 	}
-	return gatewayConfig, nil
+	if m.Delegate != nil {
+		return m.Delegate.UpdateGatewayConfig(ctx, gatewayConfig)
+	}
+	return m.defaultUpdateGatewayConfig(ctx, gatewayConfig)
 }
 
 func (m *MockGatewayConfigService) DeleteGatewayConfig(ctx context.Context, id string) error {
+	m.record("DeleteGatewayConfig", id)
 	if m.DeleteGatewayConfigFunc != nil {
 		return m.DeleteGatewayConfigFunc(ctx, id)
 	}
+	if m.Delegate != nil {
+		return m.Delegate.DeleteGatewayConfig(ctx, id)
+	}
 	return nil
 }
 
 func (m *MockGatewayConfigService) GetGatewayServerConfig(ctx context.Context, gatewayID string) (*models.GWServerConfig, error) {
+	m.record("GetGatewayServerConfig", gatewayID)
 	if m.GetGatewayServerConfigFunc != nil {
 		return m.GetGatewayServerConfigFunc(ctx, gatewayID)
 	}
-	return &models.GWServerConfig{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetGatewayServerConfig(ctx, gatewayID)
+	}
+	return m.defaultGetGatewayServerConfig(ctx, gatewayID)
 }
 
 func (m *MockGatewayConfigService) UpdateGatewayServerConfig(ctx context.Context, gatewayID string, gatewayServerConfig *models.GWServerConfig) (*models.GWServerConfig, error) {
+	m.record("UpdateGatewayServerConfig", gatewayID, gatewayServerConfig)
 	if m.UpdateGatewayServerConfigFunc != nil {
 		return m.UpdateGatewayServerConfigFunc(ctx, gatewayID, gatewayServerConfig)
 	}
-	return gatewayServerConfig, nil
+	if m.Delegate != nil {
+		return m.Delegate.UpdateGatewayServerConfig(ctx, gatewayID, gatewayServerConfig)
+	}
+	return m.defaultUpdateGatewayServerConfig(ctx, gatewayID, gatewayServerConfig)
 }
 
 // Compile-time interface compliance check
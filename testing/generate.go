@@ -0,0 +1,3 @@
+package testing
+
+//go:generate go run ./internal/mockgen -services ../services -out .
@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -9,6 +11,11 @@ import (
 
 // MockHAGroupService implements services.HAGroupServiceInterface for testing
 type MockHAGroupService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.HAGroupServiceInterface
+
 	ListFunc    func(ctx context.Context) (*[]models.HAGroup, error)
 	GetByIdFunc func(ctx context.Context, id string) (*models.HAGroup, error)
 	CreateFunc  func(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error)
@@ -17,38 +24,57 @@ type MockHAGroupService struct {
 }
 
 func (m *MockHAGroupService) List(ctx context.Context) (*[]models.HAGroup, error) {
+	m.record("List")
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx)
 	}
-	return &[]models.HAGroup{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.List(ctx)
+	}
+	return m.defaultList(ctx)
 }
 
 func (m *MockHAGroupService) GetById(ctx context.Context, id string) (*models.HAGroup, error) {
+	m.record("GetById", id)
 	if m.GetByIdFunc != nil {
 		return m.GetByIdFunc(ctx, id)
 	}
-	return &models.HAGroup{Id: id}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetById(ctx, id)
+	}
+	return m.defaultGetById(ctx, id)
 }
 
 func (m *MockHAGroupService) Create(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error) {
+	m.record("Create", hagroup)
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, hagroup)
 	}
-	hagroup.Id = "mock-hagroup-id"
-	return hagroup, nil
+	if m.Delegate != nil {
+		return m.Delegate.Create(ctx, hagroup)
+	}
+	return m.defaultCreate(ctx, hagroup)
 }
 
 func (m *MockHAGroupService) Update(ctx context.Context, hagroup *models.HAGroup) (*models.HAGroup, error) {
+	m.record("Update", hagroup)
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, hagroup)
 	}
-	return hagroup, nil
+	if m.Delegate != nil {
+		return m.Delegate.Update(ctx, hagroup)
+	}
+	return m.defaultUpdate(ctx, hagroup)
 }
 
 func (m *MockHAGroupService) Delete(ctx context.Context, id string) error {
+	m.record("Delete", id)
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
 	}
+	if m.Delegate != nil {
+		return m.Delegate.Delete(ctx, id)
+	}
 	return nil
 }
 
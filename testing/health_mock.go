@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -9,14 +11,23 @@ import (
 
 // MockHealthService implements services.HealthServiceInterface for testing
 type MockHealthService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.HealthServiceInterface
+
 	GetFunc func(ctx context.Context) (*models.Health, error)
 }
 
 func (m *MockHealthService) Get(ctx context.Context) (*models.Health, error) {
+	m.record("Get")
 	if m.GetFunc != nil {
 		return m.GetFunc(ctx)
 	}
-	return &models.Health{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.Get(ctx)
+	}
+	return m.defaultGet(ctx)
 }
 
 // Compile-time interface compliance check
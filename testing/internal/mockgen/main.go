@@ -0,0 +1,422 @@
+// Command mockgen generates the service mocks of the testing package from the interfaces in the services package.
+//
+// For every interface named XServiceInterface it writes x_mock.go containing MockXService with one XFunc field
+// per method and call recording through the embedded CallRecorder. When no XFunc is set, a method calls the
+// Delegate implementation if one is set, then defaultX if the mock defines it in a hand-written file, and returns
+// zero values otherwise.
+//
+// Usage (see testing/generate.go):
+//
+//	go run ./internal/mockgen -services ../services -out .
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	interfaceSuffix = "ServiceInterface"
+	servicesPackage = "services"
+	servicesImport  = "github.com/yehlo/storagegrid-sdk-go/services"
+	generatedSuffix = "_mock.go"
+	generatedHeader = "// Code generated by mockgen from the services package; DO NOT EDIT.\n"
+)
+
+// method is an interface method with its parameters rendered as Go source
+type method struct {
+	name     string
+	params   []param
+	results  []string
+	variadic bool
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+func main() {
+	servicesDir := flag.String("services", "../services", "directory of the services package")
+	outDir := flag.String("out", ".", "directory of the generated mocks")
+	flag.Parse()
+
+	err := run(*servicesDir, *outDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(servicesDir string, outDir string) error {
+	fset := token.NewFileSet()
+	interfaces := map[string]*ast.InterfaceType{}
+	imports := map[string]string{}
+
+	files, err := filepath.Glob(filepath.Join(servicesDir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = importPath
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+				interfaces[spec.Name.Name] = iface
+			}
+			return false
+		})
+	}
+
+	defaults, err := findDefaults(fset, outDir)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range interfaces {
+		if strings.HasSuffix(name, interfaceSuffix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		methods, err := collectMethods(name, interfaces)
+		if err != nil {
+			return err
+		}
+
+		source, err := render(name, methods, imports, defaults)
+		if err != nil {
+			return fmt.Errorf("failed to render mock for %s: %w", name, err)
+		}
+
+		base := strings.ToLower(strings.TrimSuffix(name, interfaceSuffix))
+		err = os.WriteFile(filepath.Join(outDir, base+generatedSuffix), source, 0o644) // #nosec G306
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findDefaults returns the hand-written defaultX methods per mock type in the output directory
+func findDefaults(fset *token.FileSet, dir string) (map[string]bool, error) {
+	defaults := map[string]bool{}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, generatedSuffix) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !strings.HasPrefix(fn.Name.Name, "default") {
+				continue
+			}
+
+			receiver := typeString(fn.Recv.List[0].Type, "")
+			defaults[strings.TrimPrefix(receiver, "*")+"."+fn.Name.Name] = true
+		}
+	}
+
+	return defaults, nil
+}
+
+// collectMethods returns the methods of the interface including embedded interfaces of the services package
+func collectMethods(name string, interfaces map[string]*ast.InterfaceType) ([]method, error) {
+	iface, ok := interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s not found", name)
+	}
+
+	methods := []method{}
+	for _, field := range iface.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			methods = append(methods, newMethod(field.Names[0].Name, t))
+		case *ast.Ident:
+			embedded, err := collectMethods(t.Name, interfaces)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		default:
+			return nil, fmt.Errorf("unsupported embedded type in %s", name)
+		}
+	}
+
+	return methods, nil
+}
+
+func newMethod(name string, fn *ast.FuncType) method {
+	m := method{name: name}
+
+	for _, field := range fn.Params.List {
+		typ := typeString(field.Type, servicesPackage)
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			m.variadic = true
+		}
+
+		if len(field.Names) == 0 {
+			m.params = append(m.params, param{name: fmt.Sprintf("arg%d", len(m.params)), typ: typ})
+			continue
+		}
+		for _, ident := range field.Names {
+			paramName := ident.Name
+			if paramName == "_" || paramName == "m" {
+				paramName = fmt.Sprintf("arg%d", len(m.params))
+			}
+			m.params = append(m.params, param{name: paramName, typ: typ})
+		}
+	}
+
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				m.results = append(m.results, typeString(field.Type, servicesPackage))
+			}
+		}
+	}
+
+	return m
+}
+
+// typeString renders a type expression, qualifying exported identifiers of the source package with pkg
+func typeString(expr ast.Expr, pkg string) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if pkg != "" && ast.IsExported(t.Name) {
+			return pkg + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X, "") + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X, pkg)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + typeString(t.Elt, pkg)
+		}
+		return "[" + typeString(t.Len, "") + "]" + typeString(t.Elt, pkg)
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, pkg) + "]" + typeString(t.Value, pkg)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt, pkg)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.ChanType:
+		return "chan " + typeString(t.Value, pkg)
+	case *ast.IndexExpr:
+		return typeString(t.X, pkg) + "[" + typeString(t.Index, pkg) + "]"
+	case *ast.IndexListExpr:
+		indices := []string{}
+		for _, index := range t.Indices {
+			indices = append(indices, typeString(index, pkg))
+		}
+		return typeString(t.X, pkg) + "[" + strings.Join(indices, ", ") + "]"
+	case *ast.FuncType:
+		m := newMethod("", t)
+		return "func" + m.signature()
+	default:
+		panic(fmt.Sprintf("unsupported type expression %T", expr))
+	}
+}
+
+// signature renders the parameters and results of the method
+func (m method) signature() string {
+	params := []string{}
+	for _, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+	}
+
+	signature := "(" + strings.Join(params, ", ") + ")"
+	switch len(m.results) {
+	case 0:
+	case 1:
+		signature += " " + m.results[0]
+	default:
+		signature += " (" + strings.Join(m.results, ", ") + ")"
+	}
+
+	return signature
+}
+
+// arguments renders the parameters as call arguments
+func (m method) arguments() string {
+	args := []string{}
+	for i, p := range m.params {
+		if m.variadic && i == len(m.params)-1 {
+			args = append(args, p.name+"...")
+			continue
+		}
+		args = append(args, p.name)
+	}
+
+	return strings.Join(args, ", ")
+}
+
+// recordedArguments renders the arguments stored by the call recorder, leaving out contexts
+func (m method) recordedArguments() string {
+	args := []string{strconv.Quote(m.name)}
+	for _, p := range m.params {
+		if p.typ == "context.Context" {
+			continue
+		}
+		args = append(args, p.name)
+	}
+
+	return strings.Join(args, ", ")
+}
+
+func zeroValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["),
+		strings.HasPrefix(typ, "func"), strings.HasPrefix(typ, "chan "), typ == "error", typ == "interface{}":
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"), typ == "byte", typ == "rune":
+		return "0"
+	default:
+		return "*new(" + typ + ")"
+	}
+}
+
+func render(name string, methods []method, imports map[string]string, defaults map[string]bool) ([]byte, error) {
+	mock := "Mock" + strings.TrimSuffix(name, "Interface")
+	body := &bytes.Buffer{}
+
+	fmt.Fprintf(body, "// %s implements services.%s for testing\n", mock, name)
+	fmt.Fprintf(body, "type %s struct {\n\tCallRecorder\n\n", mock)
+	fmt.Fprintf(body, "\t// Delegate handles calls without a Func, e.g. a real service to record its calls\n")
+	fmt.Fprintf(body, "\tDelegate services.%s\n\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(body, "\t%sFunc func%s\n", m.name, m.signature())
+	}
+	fmt.Fprintf(body, "}\n")
+
+	for _, m := range methods {
+		fmt.Fprintf(body, "\nfunc (m *%s) %s%s {\n", mock, m.name, m.signature())
+		fmt.Fprintf(body, "\tm.record(%s)\n", m.recordedArguments())
+		fmt.Fprintf(body, "\tif m.%sFunc != nil {\n", m.name)
+		if len(m.results) == 0 {
+			fmt.Fprintf(body, "\t\tm.%sFunc(%s)\n\t\treturn\n\t}\n", m.name, m.arguments())
+		} else {
+			fmt.Fprintf(body, "\t\treturn m.%sFunc(%s)\n\t}\n", m.name, m.arguments())
+		}
+		fmt.Fprintf(body, "\tif m.Delegate != nil {\n")
+		if len(m.results) == 0 {
+			fmt.Fprintf(body, "\t\tm.Delegate.%s(%s)\n\t\treturn\n\t}\n", m.name, m.arguments())
+		} else {
+			fmt.Fprintf(body, "\t\treturn m.Delegate.%s(%s)\n\t}\n", m.name, m.arguments())
+		}
+
+		switch {
+		case defaults[mock+".default"+m.name] && len(m.results) == 0:
+			fmt.Fprintf(body, "\tm.default%s(%s)\n", m.name, m.arguments())
+		case defaults[mock+".default"+m.name]:
+			fmt.Fprintf(body, "\treturn m.default%s(%s)\n", m.name, m.arguments())
+		case len(m.results) > 0:
+			zeros := []string{}
+			for _, result := range m.results {
+				zeros = append(zeros, zeroValue(result))
+			}
+			fmt.Fprintf(body, "\treturn %s\n", strings.Join(zeros, ", "))
+		}
+		fmt.Fprintf(body, "}\n")
+	}
+
+	fmt.Fprintf(body, "\n// Compile-time interface compliance check\nvar _ services.%s = (*%s)(nil)\n", name, mock)
+
+	// import the packages referenced by the generated code
+	used := map[string]string{servicesPackage: servicesImport}
+	for alias, importPath := range imports {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(alias) + `\.`).Match(body.Bytes()) {
+			used[alias] = importPath
+		}
+	}
+
+	standard, module := []string{}, []string{}
+	for alias, importPath := range used {
+		spec := strconv.Quote(importPath)
+		if filepath.Base(importPath) != alias {
+			spec = alias + " " + spec
+		}
+		if strings.Contains(importPath, ".") {
+			module = append(module, spec)
+		} else {
+			standard = append(standard, spec)
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(module)
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "%s\npackage testing\n\nimport (\n", generatedHeader)
+	for _, spec := range standard {
+		fmt.Fprintf(out, "\t%s\n", spec)
+	}
+	if len(standard) > 0 && len(module) > 0 {
+		fmt.Fprintf(out, "\n")
+	}
+	for _, spec := range module {
+		fmt.Fprintf(out, "\t%s\n", spec)
+	}
+	fmt.Fprintf(out, ")\n\n")
+	out.Write(body.Bytes())
+
+	return format.Source(out.Bytes())
+}
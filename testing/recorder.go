@@ -0,0 +1,234 @@
+package testing
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TestingT is the subset of *testing.T used by the call assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call of a mock method. Context arguments are not recorded, variadic arguments are recorded as one slice.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+func (c Call) String() string {
+	args := []string{}
+	for _, arg := range c.Args {
+		args = append(args, fmt.Sprintf("%#v", arg))
+	}
+
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+type anyArgument struct{}
+
+// Any matches every argument in call assertions and expectations.
+var Any = anyArgument{}
+
+// Expectation is a call expected by AssertExpectations.
+type Expectation struct {
+	method string
+	args   []interface{}
+	times  int
+}
+
+// Times sets how often the call is expected. The default is once.
+func (e *Expectation) Times(times int) *Expectation {
+	e.times = times
+	return e
+}
+
+// CallRecorder records the calls of a mock in order. It is embedded in all mocks of this package.
+//
+//	mock := &testing.MockTenantService{}
+//	// ... code under test ...
+//	mock.AssertCalled(t, "Delete", "tenant-id")
+//	mock.AssertCalledTimes(t, "List", 1)
+type CallRecorder struct {
+	mu           sync.Mutex
+	calls        []Call
+	expectations []*Expectation
+}
+
+func (r *CallRecorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in order.
+func (r *CallRecorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call{}, r.calls...)
+}
+
+// CallsTo returns the recorded calls of the method in order.
+func (r *CallRecorder) CallsTo(method string) []Call {
+	calls := []Call{}
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// CallCount returns how often the method was called.
+func (r *CallRecorder) CallCount(method string) int {
+	return len(r.CallsTo(method))
+}
+
+// Called reports whether the method was called with matching arguments.
+func (r *CallRecorder) Called(method string, args ...interface{}) bool {
+	return r.countMatching(method, args) > 0
+}
+
+// Reset forgets all recorded calls and expectations.
+func (r *CallRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+	r.expectations = nil
+}
+
+func (r *CallRecorder) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+
+	if !r.Called(method, args...) {
+		t.Errorf("expected call %s, got %s", Call{Method: method, Args: args}, r.describeCalls())
+		return false
+	}
+
+	return true
+}
+
+func (r *CallRecorder) AssertNotCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+
+	if r.Called(method, args...) {
+		t.Errorf("unexpected call %s", Call{Method: method, Args: args})
+		return false
+	}
+
+	return true
+}
+
+func (r *CallRecorder) AssertCalledTimes(t TestingT, method string, times int) bool {
+	t.Helper()
+
+	if count := r.CallCount(method); count != times {
+		t.Errorf("expected %s to be called %d times, got %d", method, times, count)
+		return false
+	}
+
+	return true
+}
+
+// AssertCallOrder checks that the methods were called in the given order, other calls in between are allowed.
+func (r *CallRecorder) AssertCallOrder(t TestingT, methods ...string) bool {
+	t.Helper()
+
+	next := 0
+	for _, call := range r.Calls() {
+		if next < len(methods) && call.Method == methods[next] {
+			next++
+		}
+	}
+
+	if next < len(methods) {
+		t.Errorf("expected calls in order %s, got %s", strings.Join(methods, ", "), r.describeCalls())
+		return false
+	}
+
+	return true
+}
+
+// ExpectCall registers a call checked by AssertExpectations.
+func (r *CallRecorder) ExpectCall(method string, args ...interface{}) *Expectation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expectation := &Expectation{method: method, args: args, times: 1}
+	r.expectations = append(r.expectations, expectation)
+
+	return expectation
+}
+
+// AssertExpectations checks that every expected call was made exactly as often as expected.
+func (r *CallRecorder) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	r.mu.Lock()
+	expectations := append([]*Expectation{}, r.expectations...)
+	r.mu.Unlock()
+
+	ok := true
+	for _, expectation := range expectations {
+		count := r.countMatching(expectation.method, expectation.args)
+		if count != expectation.times {
+			t.Errorf("expected call %s %d times, got %d", Call{Method: expectation.method, Args: expectation.args}, expectation.times, count)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func (r *CallRecorder) countMatching(method string, args []interface{}) int {
+	count := 0
+	for _, call := range r.CallsTo(method) {
+		if matchArguments(call.Args, args) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// matchArguments compares recorded arguments with expected ones. No expected arguments match any call.
+func matchArguments(actual []interface{}, expected []interface{}) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	for i := range expected {
+		if expected[i] == Any {
+			continue
+		}
+		if !reflect.DeepEqual(actual[i], expected[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *CallRecorder) describeCalls() string {
+	calls := r.Calls()
+	if len(calls) == 0 {
+		return "no calls"
+	}
+
+	described := []string{}
+	for _, call := range calls {
+		described = append(described, call.String())
+	}
+
+	return strings.Join(described, ", ")
+}
@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -8,14 +10,23 @@ import (
 
 // MockRegionService implements services.RegionServiceInterface for testing
 type MockRegionService struct {
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.RegionServiceInterface
+
 	ListFunc func(ctx context.Context) (*[]string, error)
 }
 
 func (m *MockRegionService) List(ctx context.Context) (*[]string, error) {
+	m.record("List")
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx)
 	}
-	return &[]string{"us-east-1", "us-west-2"}, nil
+	if m.Delegate != nil {
+		return m.Delegate.List(ctx)
+	}
+	return m.defaultList(ctx)
 }
 
 // Compile-time interface compliance check
@@ -1,3 +1,5 @@
+// Code generated by mockgen from the services package; DO NOT EDIT.
+
 package testing
 
 import (
@@ -9,65 +11,118 @@ import (
 
 // MockTenantUserService implements services.TenantUserServiceInterface for testing
 type MockTenantUserService struct {
-	ListFunc        func(ctx context.Context) (*[]models.User, error)
-	GetByIdFunc     func(ctx context.Context, id string) (*models.User, error)
-	GetByNameFunc   func(ctx context.Context, name string) (*models.User, error)
-	CreateFunc      func(ctx context.Context, user *models.User) (*models.User, error)
-	UpdateFunc      func(ctx context.Context, user *models.User) (*models.User, error)
-	DeleteFunc      func(ctx context.Context, id string) error
-	SetPasswordFunc func(ctx context.Context, id string, password string) error
+	CallRecorder
+
+	// Delegate handles calls without a Func, e.g. a real service to record its calls
+	Delegate services.TenantUserServiceInterface
+
+	ListFunc            func(ctx context.Context, opts ...services.ListOption) (*[]models.User, error)
+	IterFunc            func(opts ...services.ListOption) *services.ListIter[models.User]
+	GetByIdFunc         func(ctx context.Context, id string) (*models.User, error)
+	GetByNameFunc       func(ctx context.Context, name string) (*models.User, error)
+	CreateFunc          func(ctx context.Context, user *models.User) (*models.User, error)
+	CreateFederatedFunc func(ctx context.Context, user *models.User) (*models.User, error)
+	UpdateFunc          func(ctx context.Context, user *models.User) (*models.User, error)
+	DeleteFunc          func(ctx context.Context, id string) error
+	SetPasswordFunc     func(ctx context.Context, id string, password string) error
 }
 
-func (m *MockTenantUserService) List(ctx context.Context) (*[]models.User, error) {
+func (m *MockTenantUserService) List(ctx context.Context, opts ...services.ListOption) (*[]models.User, error) {
+	m.record("List", opts)
 	if m.ListFunc != nil {
-		return m.ListFunc(ctx)
+		return m.ListFunc(ctx, opts...)
 	}
-	return &[]models.User{}, nil
+	if m.Delegate != nil {
+		return m.Delegate.List(ctx, opts...)
+	}
+	return m.defaultList(ctx, opts...)
+}
+
+func (m *MockTenantUserService) Iter(opts ...services.ListOption) *services.ListIter[models.User] {
+	m.record("Iter", opts)
+	if m.IterFunc != nil {
+		return m.IterFunc(opts...)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.Iter(opts...)
+	}
+	return m.defaultIter(opts...)
 }
 
 func (m *MockTenantUserService) GetById(ctx context.Context, id string) (*models.User, error) {
+	m.record("GetById", id)
 	if m.GetByIdFunc != nil {
 		return m.GetByIdFunc(ctx, id)
 	}
-	mockId := id
-	return &models.User{Id: &mockId}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetById(ctx, id)
+	}
+	return m.defaultGetById(ctx, id)
 }
 
 func (m *MockTenantUserService) GetByName(ctx context.Context, name string) (*models.User, error) {
+	m.record("GetByName", name)
 	if m.GetByNameFunc != nil {
 		return m.GetByNameFunc(ctx, name)
 	}
-	mockId := "mock-user-id"
-	return &models.User{Id: &mockId, UniqueName: name}, nil
+	if m.Delegate != nil {
+		return m.Delegate.GetByName(ctx, name)
+	}
+	return m.defaultGetByName(ctx, name)
 }
 
 func (m *MockTenantUserService) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	m.record("Create", user)
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, user)
 	}
-	mockId := "mock-user-id"
-	user.Id = &mockId
-	return user, nil
+	if m.Delegate != nil {
+		return m.Delegate.Create(ctx, user)
+	}
+	return m.defaultCreate(ctx, user)
+}
+
+func (m *MockTenantUserService) CreateFederated(ctx context.Context, user *models.User) (*models.User, error) {
+	m.record("CreateFederated", user)
+	if m.CreateFederatedFunc != nil {
+		return m.CreateFederatedFunc(ctx, user)
+	}
+	if m.Delegate != nil {
+		return m.Delegate.CreateFederated(ctx, user)
+	}
+	return m.defaultCreateFederated(ctx, user)
 }
 
 func (m *MockTenantUserService) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	m.record("Update", user)
 	if m.UpdateFunc != nil {
 		return m.UpdateFunc(ctx, user)
 	}
-	return user, nil
+	if m.Delegate != nil {
+		return m.Delegate.Update(ctx, user)
+	}
+	return m.defaultUpdate(ctx, user)
 }
 
 func (m *MockTenantUserService) Delete(ctx context.Context, id string) error {
+	m.record("Delete", id)
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
 	}
+	if m.Delegate != nil {
+		return m.Delegate.Delete(ctx, id)
+	}
 	return nil
 }
 
 func (m *MockTenantUserService) SetPassword(ctx context.Context, id string, password string) error {
+	m.record("SetPassword", id, password)
 	if m.SetPasswordFunc != nil {
 		return m.SetPasswordFunc(ctx, id, password)
 	}
+	if m.Delegate != nil {
+		return m.Delegate.SetPassword(ctx, id, password)
+	}
 	return nil
 }
 